@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/batiazinga/goodstein/decomposition"
+	"github.com/batiazinga/goodstein/ordinal"
+)
+
+// certificateHeader is the first line of certificates.
+const certificateHeader = "# goodstein termination certificate: iteration base decomposition ordinal"
+
+// certificate writes a termination certificate:
+// each iteration is written with the ordinal of its decomposition (base replaced by ω)
+// and the strict decrease of ordinals is asserted along the way.
+// Since there is no infinite decreasing sequence of ordinals, this proves termination.
+type certificate struct {
+	w        io.Writer
+	previous ordinal.Ordinal
+	started  bool
+}
+
+// newCertificate returns a certificate written to w and writes its header.
+func newCertificate(w io.Writer) *certificate {
+	fmt.Fprintln(w, certificateHeader)
+	return &certificate{w: w}
+}
+
+// write writes the i-th iteration.
+// It returns an error if its ordinal is not lower than the one of the previous iteration.
+func (c *certificate) write(i *big.Int, d decomposition.Decomposition) error {
+	a := d.ToOrdinal()
+	if c.started && a.Cmp(c.previous) >= 0 {
+		return fmt.Errorf("iteration %v: ordinal %v is not lower than %v", i, a, c.previous)
+	}
+	c.previous, c.started = a, true
+
+	_, err := fmt.Fprintf(c.w, "%v %v %q %q\n", i, d.Base(), d, a)
+	return err
+}
+
+// end writes the conclusion of the certificate.
+func (c *certificate) end(terminated bool) {
+	if terminated {
+		fmt.Fprintln(c.w, "# terminated: ordinals strictly decreased down to 0")
+		return
+	}
+	fmt.Fprintln(c.w, "# not terminated: ordinals strictly decreased so far")
+}
+
+// certifyCommand checks a termination certificate:
+// each decomposition must result from a Goodstein step applied to the previous one,
+// with any base rule and subtraction, i.e. be lower than the previous one in its base,
+// and must be mapped to the claimed ordinal, which must be strictly lower than the previous one.
+func certifyCommand(args []string) error {
+	flags := flag.NewFlagSet("certify", flag.ExitOnError)
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expecting one certificate file")
+	}
+
+	f, err := openInput(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var (
+		steps    int
+		previous decomposition.Decomposition
+		started  bool
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<30) // decompositions may be very long
+	for scanner.Scan() {
+		text := scanner.Text()
+		if strings.HasPrefix(text, "#") || text == "" {
+			continue
+		}
+		d, claimed, err := parseCertificateLine(text)
+		if err != nil {
+			return err
+		}
+
+		// claimed ordinal
+		a := d.ToOrdinal()
+		if a.String() != claimed {
+			return fmt.Errorf("ordinal of %q is %v, not %v", d, a, claimed)
+		}
+
+		// step and strict decrease
+		if started {
+			if previous.IsZero() {
+				return fmt.Errorf("step after zero")
+			}
+			// whatever the base rule, the base must increase
+			if d.Base().Cmp(previous.Base()) <= 0 {
+				return fmt.Errorf("base %v is not greater than %v", d.Base(), previous.Base())
+			}
+			bumped, err := previous.WithBaseBig(d.Base())
+			if err != nil {
+				return err
+			}
+			if d.Cmp(bumped) >= 0 {
+				return fmt.Errorf("%q does not follow %q", d, previous)
+			}
+			if a.Cmp(previous.ToOrdinal()) >= 0 {
+				return fmt.Errorf("ordinal %v is not lower than %v", a, previous.ToOrdinal())
+			}
+			steps++
+		}
+		previous, started = d, true
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if !started {
+		return fmt.Errorf("empty certificate")
+	}
+
+	fmt.Fprintf(os.Stdout, "valid certificate: %v steps, terminated: %v\n", steps, previous.IsZero())
+	return nil
+}
+
+// parseCertificateLine parses an iteration line of a certificate
+// and returns its decomposition and claimed ordinal.
+func parseCertificateLine(text string) (decomposition.Decomposition, string, error) {
+	fields := strings.SplitN(text, " ", 3)
+	if len(fields) != 3 {
+		return decomposition.Decomposition{}, "", fmt.Errorf("invalid line %q", text)
+	}
+	b, ok := new(big.Int).SetString(fields[1], 10)
+	if !ok {
+		return decomposition.Decomposition{}, "", fmt.Errorf("invalid base %q", fields[1])
+	}
+
+	// quoted decomposition and ordinal
+	var s, claimed string
+	if _, err := fmt.Sscanf(fields[2], "%q %q", &s, &claimed); err != nil {
+		return decomposition.Decomposition{}, "", fmt.Errorf("invalid line %q: %v", text, err)
+	}
+	d, err := decomposition.ParseBaseBig(b, s)
+	if err != nil {
+		return decomposition.Decomposition{}, "", err
+	}
+	return d, claimed, nil
+}
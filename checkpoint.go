@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// checkpointVersion is the version of the binary encoding of checkpoints,
+// which follows their magic string.
+const checkpointVersion = 1
+
+// checkpointMagic starts every checkpoint file.
+const checkpointMagic = "goodstein checkpoint\n"
+
+// errTruncatedCheckpoint is returned when a checkpoint ends unexpectedly.
+var errTruncatedCheckpoint = errors.New("truncated checkpoint")
+
+// checkpoint is the saved state of a sequence:
+// its last computed iteration, with the rules needed to compute the next ones.
+type checkpoint struct {
+	state
+	bump     string // -bump flag value
+	subtract int
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is the magic string and the version byte,
+// followed by the iteration, the bump rule and the subtracted number
+// and the binary encoding of the decomposition, which holds the base.
+// The iteration and the bump rule are encoded as the length of their bytes,
+// as an unsigned varint, followed by these bytes; the subtracted number is an unsigned varint.
+func (c checkpoint) MarshalBinary() ([]byte, error) {
+	d, err := c.d.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	data := append([]byte(checkpointMagic), checkpointVersion)
+	iteration := c.iteration.Bytes()
+	data = binary.AppendUvarint(data, uint64(len(iteration)))
+	data = append(data, iteration...)
+	data = binary.AppendUvarint(data, uint64(len(c.bump)))
+	data = append(data, c.bump...)
+	data = binary.AppendUvarint(data, uint64(c.subtract))
+	return append(data, d...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (c *checkpoint) UnmarshalBinary(data []byte) error {
+	if len(data) <= len(checkpointMagic) || string(data[:len(checkpointMagic)]) != checkpointMagic {
+		return errors.New("not a checkpoint")
+	}
+	data = data[len(checkpointMagic):]
+	if data[0] != checkpointVersion {
+		return fmt.Errorf("unsupported checkpoint version %v", data[0])
+	}
+	data = data[1:]
+
+	// length-prefixed bytes
+	readBytes := func() ([]byte, error) {
+		n, read := binary.Uvarint(data)
+		if read <= 0 || n > uint64(len(data)-read) {
+			return nil, errTruncatedCheckpoint
+		}
+		b := data[read : read+int(n)]
+		data = data[read+int(n):]
+		return b, nil
+	}
+
+	iteration, err := readBytes()
+	if err != nil {
+		return err
+	}
+	bump, err := readBytes()
+	if err != nil {
+		return err
+	}
+	subtract, read := binary.Uvarint(data)
+	if read <= 0 {
+		return errTruncatedCheckpoint
+	}
+	data = data[read:]
+
+	var d decomposition.Decomposition
+	if err := d.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("invalid checkpoint decomposition: %v", err)
+	}
+	if d.Base().Sign() == 0 {
+		return errors.New("invalid checkpoint decomposition: no base")
+	}
+
+	*c = checkpoint{
+		state:    state{iteration: new(big.Int).SetBytes(iteration), d: d},
+		bump:     string(bump),
+		subtract: int(subtract),
+	}
+	return nil
+}
+
+// writeCheckpoint saves the checkpoint in the named file,
+// gzip-compressed if its name ends with .gz like other outputs.
+// It is written to a temporary file first and then renamed,
+// so that the previous checkpoint is kept if the program is interrupted meanwhile.
+func writeCheckpoint(name string, c checkpoint) error {
+	data, err := c.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	// the temporary file has the same extension, hence the same compression
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*"+filepath.Ext(name))
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	f, err := createOutput(tmp.Name(), false)
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// readCheckpoint reads the checkpoint saved in the named file,
+// decompressed if its name ends with .gz.
+func readCheckpoint(name string) (checkpoint, error) {
+	f, err := openInput(name)
+	if err != nil {
+		return checkpoint{}, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return checkpoint{}, fmt.Errorf("%v: %v", name, err)
+	}
+	var c checkpoint
+	if err := c.UnmarshalBinary(data); err != nil {
+		return checkpoint{}, fmt.Errorf("%v: %v", name, err)
+	}
+	return c, nil
+}
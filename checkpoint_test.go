@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+func TestCheckpoint(t *testing.T) {
+	d, _ := decomposition.Parse("2 * 5 ^ (2) + 2 * 5")
+	c := checkpoint{state: state{iteration: big.NewInt(3), d: d}, bump: "+1", subtract: 1}
+
+	for _, name := range []string{"checkpoint", "checkpoint.gz"} {
+		name = filepath.Join(t.TempDir(), name)
+		if err := writeCheckpoint(name, c); err != nil {
+			t.Fatal(err)
+		}
+		got, err := readCheckpoint(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.iteration.Cmp(c.iteration) != 0 || !got.d.Equal(c.d) || got.bump != c.bump || got.subtract != c.subtract {
+			t.Errorf("%v: got %v %q %v %v", name, got.iteration, got.d, got.bump, got.subtract)
+		}
+	}
+}
+
+func TestCheckpointCompressed(t *testing.T) {
+	d, _ := decomposition.New(2, 4)
+	c := checkpoint{state: state{iteration: new(big.Int), d: d}, bump: "+1", subtract: 1}
+	name := filepath.Join(t.TempDir(), "checkpoint.gz")
+	if err := writeCheckpoint(name, c); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(name)
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Errorf("checkpoint is not gzip-compressed")
+	}
+	if err := writeCheckpoint(filepath.Join(t.TempDir(), "checkpoint.zst"), c); err == nil {
+		t.Errorf("zstd checkpoint accepted")
+	}
+}
+
+func TestCheckpointCorrupt(t *testing.T) {
+	d, _ := decomposition.New(2, 4)
+	valid, _ := checkpoint{state: state{iteration: big.NewInt(3), d: d}, bump: "+1", subtract: 1}.MarshalBinary()
+	noBase, _ := checkpoint{state: state{iteration: big.NewInt(3)}, bump: "+1", subtract: 1}.MarshalBinary()
+
+	for _, data := range [][]byte{
+		nil,
+		[]byte("not a checkpoint"),
+		[]byte(checkpointMagic),
+		valid[:len(checkpointMagic)+3], // truncated
+		noBase,
+	} {
+		var c checkpoint
+		if err := c.UnmarshalBinary(data); err == nil {
+			t.Errorf("corrupt checkpoint %q accepted", data)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package main
+
+// commands are the subcommands of goodstein.
+// They take the arguments following the subcommand name.
+// Without any subcommand, goodstein prints a Goodstein sequence.
+var commands = map[string]func(args []string) error{
+	"certify": certifyCommand,
+	"compare": compareCommand,
+	"convert": convertCommand,
+	"eq":      eqCommand,
+	"eval":    evalCommand,
+	"hydra":   hydraCommand,
+	"worm":    wormCommand,
+}
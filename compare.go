@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// chartWidth is the maximum number of columns of the comparison chart.
+const chartWidth = 60
+
+// chartLevels are the characters of the comparison chart, from lowest to highest.
+var chartLevels = []rune("▁▂▃▄▅▆▇█")
+
+// run is the result of a Goodstein sequence, computed or loaded from an output file.
+type run struct {
+	name       string
+	digits     []int // number of digits of the value at each iteration, 0 if unknown
+	terminated bool  // true if the sequence reached zero
+	descent    int   // first iteration whose value is lower than the previous one, -1 if none
+}
+
+// compareCommand runs or loads several sequences and
+// prints a combined comparison table and chart.
+// Integer arguments are seeds to be run, other arguments are output files to be loaded.
+func compareCommand(args []string) error {
+	flags := flag.NewFlagSet("compare", flag.ExitOnError)
+	maxIt := flags.Int("it", 10, "maximum number of iterations of each computed sequence")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		return fmt.Errorf("expecting seeds or output files")
+	}
+	if *maxIt < 0 {
+		return fmt.Errorf("it must be positive")
+	}
+
+	// compute or load all runs
+	runs := make([]run, flags.NArg())
+	for i, arg := range flags.Args() {
+		var err error
+		if seed, errSeed := strconv.Atoi(arg); errSeed == nil {
+			runs[i], err = runSeed(seed, *maxIt)
+		} else {
+			runs[i], err = loadRun(arg)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %v", arg, err)
+		}
+	}
+
+	// comparison table
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "run\titerations\tterminated\tmax digits\tdescent")
+	for _, r := range runs {
+		descent := "-"
+		if r.descent >= 0 {
+			descent = strconv.Itoa(r.descent)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", r.name, len(r.digits), r.terminated, r.maxDigits(), descent)
+	}
+	w.Flush()
+
+	// combined chart
+	fmt.Fprintln(os.Stdout)
+	fmt.Fprintln(os.Stdout, "digits per iteration:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, r := range runs {
+		fmt.Fprintf(w, "%v\t%v\n", r.name, r.chart(maxDigits(runs)))
+	}
+	return w.Flush()
+}
+
+// runSeed computes at most maxIt iterations of the sequence starting at seed.
+func runSeed(seed, maxIt int) (run, error) {
+	d, err := decomposition.New(2, seed)
+	if err != nil {
+		return run{}, err
+	}
+
+	r := run{name: strconv.Itoa(seed), descent: -1}
+	var previous *big.Int
+	for i := 0; i < maxIt; i++ {
+		value := d.Eval()
+		r.add(i, value, previous)
+		previous = value
+
+		if d.IsZero() {
+			r.terminated = true
+			break
+		}
+		d = d.IncrementBase().Decrement()
+	}
+	return r, nil
+}
+
+// loadRun loads a run from an output file of the command.
+func loadRun(name string) (run, error) {
+	f, err := openInput(name)
+	if err != nil {
+		return run{}, err
+	}
+	defer f.Close()
+
+	r := run{name: name, descent: -1}
+	var previous *big.Int
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<30) // decompositions may be very long
+	for scanner.Scan() {
+		if !isIterationLine(scanner.Text()) {
+			continue
+		}
+		l, err := parseLine(scanner.Text())
+		if err != nil {
+			return run{}, err
+		}
+		r.add(len(r.digits), l.value, previous)
+		previous = l.value
+		// without values (-no-value), the decomposition tells whether the sequence terminated
+		if l.value != nil {
+			r.terminated = l.value.Sign() == 0
+		} else {
+			r.terminated = l.decomposition == "0"
+		}
+	}
+	return r, scanner.Err()
+}
+
+// add adds the value of the i-th iteration to the run.
+// value and previous may be nil if unknown.
+func (r *run) add(i int, value, previous *big.Int) {
+	digits := 0
+	if value != nil {
+		digits = len(value.String())
+	}
+	r.digits = append(r.digits, digits)
+
+	if r.descent < 0 && value != nil && previous != nil && value.Cmp(previous) < 0 {
+		r.descent = i
+	}
+}
+
+// maxDigits returns the maximum number of digits of the run.
+func (r run) maxDigits() int {
+	max := 0
+	for _, d := range r.digits {
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// maxDigits returns the maximum number of digits among all runs.
+func maxDigits(runs []run) int {
+	max := 0
+	for _, r := range runs {
+		if d := r.maxDigits(); d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// chart returns a one-line chart of the number of digits per iteration,
+// scaled so that max is the highest level.
+// Iterations are grouped so that the chart is at most chartWidth wide.
+func (r run) chart(max int) string {
+	if max == 0 {
+		return ""
+	}
+
+	group := (len(r.digits) + chartWidth - 1) / chartWidth
+	var sb strings.Builder
+	for i := 0; i < len(r.digits); i += group {
+		// highest number of digits in the group
+		high := 0
+		for j := i; j < i+group && j < len(r.digits); j++ {
+			if r.digits[j] > high {
+				high = r.digits[j]
+			}
+		}
+		sb.WriteRune(chartLevels[(high*(len(chartLevels)-1)+max-1)/max])
+	}
+	return sb.String()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadRun(t *testing.T) {
+	for _, g := range []struct {
+		output     string
+		terminated bool
+	}{
+		{"iteration base value decomposition\n0 2 1 \"1\"\n1 3 0 \"0\"\n", true},
+		{"iteration base value decomposition\n0 2 1 \"1\"\n", false},
+		// -no-value
+		{"iteration base value decomposition\n0 2 - \"1\"\n1 3 - \"0\"\n", true},
+		{"iteration base value decomposition\n0 2 - \"1\"\n", false},
+	} {
+		name := filepath.Join(t.TempDir(), "output")
+		if err := os.WriteFile(name, []byte(g.output), 0644); err != nil {
+			t.Fatal(err)
+		}
+		r, err := loadRun(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.terminated != g.terminated {
+			t.Errorf("%q: got terminated %v, expecting %v", g.output, r.terminated, g.terminated)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// convertCommand reads decompositions on stdin, one per line,
+// and writes them in another format on stdout.
+func convertCommand(args []string) error {
+	flags := flag.NewFlagSet("convert", flag.ExitOnError)
+	from := flags.String("from", "string", "input format, one of "+formatNames())
+	to := flags.String("to", "latex", "output format, one of "+formatNames())
+	base := flags.Int("base", 0, "base of the input decompositions, inferred if zero (when possible)")
+	flags.Parse(args)
+	if flags.NArg() != 0 {
+		return fmt.Errorf("expecting no argument, decompositions are read on stdin")
+	}
+
+	// look for formats
+	in, ok := formats[*from]
+	if !ok || in.parse == nil {
+		return fmt.Errorf("unknown input format %q", *from)
+	}
+	out, ok := formats[*to]
+	if !ok {
+		return fmt.Errorf("unknown output format %q", *to)
+	}
+
+	// convert line by line
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1<<30) // decompositions may be very long
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		d, err := in.parse(scanner.Text(), *base)
+		if err != nil {
+			return fmt.Errorf("line %v: %v", line, err)
+		}
+		fmt.Fprintln(w, out.format(d))
+	}
+	return scanner.Err()
+}
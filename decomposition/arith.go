@@ -0,0 +1,150 @@
+package decomposition
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// addMonome returns the base-b terms t plus 'coeff * b ^ exp'.
+// t and exp must be clean and coeff must be positive.
+// coeff may be greater than or equal to the base:
+// carries are propagated to the next exponents.
+// The original terms (and coeff) are left unchanged.
+func (t terms) addMonome(b, coeff *big.Int, exp terms) terms {
+	// find where the monome goes
+	i := sort.Search(len(t), func(i int) bool { return t[i].exponent.cmp(exp) >= 0 })
+	rest := t[i:]
+	if i < len(t) && t[i].exponent.cmp(exp) == 0 {
+		// merge with the monome sharing the same exponent
+		coeff = new(big.Int).Add(coeff, t[i].coeff)
+		rest = t[i+1:]
+	}
+
+	// keep the remainder here
+	q, r := new(big.Int).QuoRem(coeff, b, new(big.Int))
+	sum := make(terms, 0, len(t)+1)
+	sum = append(sum, t[:i]...)
+	if r.Sign() != 0 {
+		sum = append(sum, monome{
+			coeff:    r,
+			exponent: exp,
+		})
+	}
+	sum = append(sum, rest...)
+
+	// and carry the quotient over to the next exponent
+	if q.Sign() != 0 {
+		sum = sum.addMonome(b, q, exp.addMonome(b, bigOne, nil))
+	}
+
+	// an empty decomposition is always nil
+	if len(sum) == 0 {
+		return nil
+	}
+	return sum
+}
+
+// MulScalar returns the decomposition of c times the value of the decomposition,
+// computed symbolically: coefficients are multiplied by c
+// and carries are propagated to the next exponents.
+// c must be non negative, otherwise it panics.
+// The original decomposition is left unchanged.
+func (d Decomposition) MulScalar(c int) Decomposition {
+	if c < 0 {
+		panic(fmt.Sprintf("decomposition: negative scalar %v", c))
+	}
+	if c == 0 {
+		return newDecomposition(d.base, nil)
+	}
+
+	factor := big.NewInt(int64(c))
+	var product terms
+	for _, m := range d.monomes {
+		product = product.addMonome(d.base, new(big.Int).Mul(m.coeff, factor), m.exponent)
+	}
+	return newDecomposition(d.base, product)
+}
+
+// SubScalar returns the decomposition of the value of the decomposition minus c,
+// or zero if c is greater than the value, computed symbolically:
+// the constant monome is decreased as long as it is large enough,
+// otherwise it is removed and the rest is decremented like in Decrement.
+// c must be non negative, otherwise it panics.
+// The original decomposition is left unchanged.
+func (d Decomposition) SubScalar(c int) Decomposition {
+	if c < 0 {
+		panic(fmt.Sprintf("decomposition: negative scalar %v", c))
+	}
+	return newDecomposition(d.base, d.monomes.subScalar(d.base, big.NewInt(int64(c))))
+}
+
+// subScalar returns the base-b terms t minus c, or zero if c is greater than t.
+// c must be non negative; t and c are left unchanged.
+func (t terms) subScalar(b, c *big.Int) terms {
+	c = new(big.Int).Set(c)
+	for c.Sign() > 0 && !t.isZero() {
+		// constant monome, if any
+		r := new(big.Int)
+		if t[0].exponent.isZero() {
+			r = t[0].coeff
+		}
+
+		// subtract from the constant monome
+		if r.Cmp(c) >= 0 {
+			diff := new(big.Int).Sub(r, c)
+			rest := t.withoutConstant()
+			if diff.Sign() == 0 {
+				// an empty decomposition is always nil
+				if len(rest) == 0 {
+					return nil
+				}
+				return rest
+			}
+			return append(terms{{coeff: diff}}, rest...)
+		}
+
+		// or remove it and borrow one from the next monome
+		c.Sub(c, r).Sub(c, bigOne)
+		t, _ = t.withoutConstant().decrement(b, 0)
+	}
+	return t
+}
+
+// add returns the base-b terms t plus u.
+// Both must be clean; they are left unchanged.
+func (t terms) add(b *big.Int, u terms) terms {
+	sum := t
+	for _, m := range u {
+		sum = sum.addMonome(b, m.coeff, m.exponent)
+	}
+	return sum
+}
+
+// Mul returns the decomposition of the product of the values of a and b,
+// computed symbolically: exponents of each pair of monomes are added
+// and carries are propagated to the next exponents.
+// a and b must have the same base, unless one of them is zero.
+func Mul(a, b Decomposition) (Decomposition, error) {
+	switch {
+	case a.IsZero() && b.IsZero():
+		if a.base == nil {
+			return newDecomposition(b.base, nil), nil
+		}
+		return newDecomposition(a.base, nil), nil
+	case a.IsZero():
+		return newDecomposition(b.base, nil), nil
+	case b.IsZero():
+		return newDecomposition(a.base, nil), nil
+	case a.base.Cmp(b.base) != 0:
+		return Decomposition{}, fmt.Errorf("%w: cannot multiply base-%v and base-%v decompositions", ErrBaseMismatch, a.base, b.base)
+	}
+
+	var product terms
+	for _, ma := range a.monomes {
+		for _, mb := range b.monomes {
+			product = product.addMonome(a.base, new(big.Int).Mul(ma.coeff, mb.coeff), ma.exponent.add(a.base, mb.exponent))
+		}
+	}
+	return newDecomposition(a.base, product), nil
+}
@@ -0,0 +1,122 @@
+package decomposition
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestAddMonome(t *testing.T) {
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 50; n++ {
+			d, _ := New(b, n)
+			for coeff := 1; coeff < 3*b; coeff++ {
+				for e := 0; e < 3; e++ {
+					exp, _ := New(b, e)
+					sum := d.monomes.addMonome(big.NewInt(int64(b)), big.NewInt(int64(coeff)), exp.monomes)
+
+					// sum must be valid
+					if !valid(sum, b) {
+						t.Errorf("invalid sum of %q and %v * %v ^ %v", d, coeff, b, e)
+					}
+
+					// and have the expected value
+					expected := new(big.Int).Exp(big.NewInt(int64(b)), big.NewInt(int64(e)), nil)
+					expected.Mul(expected, big.NewInt(int64(coeff)))
+					expected.Add(expected, big.NewInt(int64(n)))
+					if v := sum.eval(big.NewInt(int64(b))); v.Cmp(expected) != 0 {
+						t.Errorf("%q + %v * %v ^ %v = %v, expecting %v", d, coeff, b, e, v, expected)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMulScalar(t *testing.T) {
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 50; n++ {
+			d, _ := New(b, n)
+			for c := 0; c < 3*b; c++ {
+				expected, _ := New(b, c*n)
+				if product := d.MulScalar(c); !product.Equal(expected) {
+					t.Errorf("%v * %q: expected %q, got %q", c, d, expected, product)
+				}
+			}
+		}
+	}
+}
+
+func TestSubScalar(t *testing.T) {
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 50; n++ {
+			d, _ := New(b, n)
+			for c := 0; c < 3*b; c++ {
+				expected, _ := New(b, max(n-c, 0))
+				difference := d.SubScalar(c)
+				if !difference.Equal(expected) {
+					t.Errorf("%q - %v: expected %q, got %q", d, c, expected, difference)
+				}
+				if !valid(difference.monomes, b) {
+					t.Errorf("%q - %v: invalid difference", d, c)
+				}
+			}
+		}
+	}
+}
+
+func TestMul(t *testing.T) {
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 30; n++ {
+			x, _ := New(b, n)
+			for m := 0; m < 30; m++ {
+				y, _ := New(b, m)
+				expected, _ := New(b, n*m)
+				product, err := Mul(x, y)
+				if err != nil {
+					t.Errorf("%q * %q: unexpected error %v", x, y, err)
+					continue
+				}
+				if !product.Equal(expected) {
+					t.Errorf("%q * %q: expected %q, got %q", x, y, expected, product)
+				}
+			}
+		}
+	}
+
+	// towers
+	x, _ := New(2, 16)      // 2 ^ (2 ^ (2))
+	product, _ := Mul(x, x) // exponents 2 ^ (2) + 2 ^ (2) carry into 2 ^ (2 + 1)
+	if s := product.String(); s != "2 ^ (2 ^ (2 + 1))" {
+		t.Errorf("wrong product %q", s)
+	}
+
+	// bases must match
+	y, _ := New(3, 2)
+	if _, err := Mul(x, y); err == nil {
+		t.Errorf("base mismatch: expecting an error")
+	}
+
+	// unless one operand is zero
+	var zero Decomposition
+	if product, err := Mul(zero, y); err != nil || !product.IsZero() || product.Base().Int64() != 3 {
+		t.Errorf("zero product: got %v (base %v), %v", product, product.Base(), err)
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	for b := 2; b < 6; b++ {
+		for n := 0; n < 200; n++ {
+			d, _ := New(b, n)
+			expected, _ := New(b, n+1)
+			incremented := d.Increment()
+			if !incremented.Equal(expected) {
+				t.Errorf("%q + 1: expected %q, got %q", d, expected, incremented)
+			}
+
+			// round trip
+			if decremented := incremented.Decrement(); !decremented.Equal(d) {
+				t.Errorf("%q + 1 - 1: got %q", d, decremented)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package decomposition
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// binaryVersion is the version of the binary encoding of decompositions,
+// which is its first byte.
+// It is incremented whenever the encoding changes,
+// older versions remaining decodable.
+const binaryVersion = 1
+
+// errTruncated is returned when binary data ends unexpectedly.
+var errTruncated = errors.New("truncated binary decomposition")
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is the version byte followed by the base and the monomes,
+// from the least significant to the most significant one.
+// Monomes are preceded by their number, as an unsigned varint,
+// and each of them is its coefficient followed by the monomes of its exponent.
+// Base and coefficients are encoded as the length of their big-endian bytes,
+// as an unsigned varint, followed by these bytes.
+// The base of the default value of Decomposition has no bytes.
+func (d Decomposition) MarshalBinary() ([]byte, error) {
+	data := []byte{binaryVersion}
+	data = appendBigInt(data, d.base)
+	return d.monomes.appendBinary(data), nil
+}
+
+// appendBinary appends the binary encoding of the terms to data.
+func (t terms) appendBinary(data []byte) []byte {
+	data = binary.AppendUvarint(data, uint64(len(t)))
+	for _, m := range t {
+		data = appendBigInt(data, m.coeff)
+		data = m.exponent.appendBinary(data)
+	}
+	return data
+}
+
+// appendBigInt appends the length of the big-endian bytes of n and these bytes to data.
+// n must not be negative, nil is encoded like 0.
+func appendBigInt(data []byte, n *big.Int) []byte {
+	if n == nil {
+		return binary.AppendUvarint(data, 0)
+	}
+	bytes := n.Bytes()
+	data = binary.AppendUvarint(data, uint64(len(bytes)))
+	return append(data, bytes...)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// The decoded decomposition must be valid and must not exceed DefaultLimits.
+func (d *Decomposition) UnmarshalBinary(data []byte) error {
+	decoded, err := UnmarshalBinaryWith(data, ParseOptions{})
+	if err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// UnmarshalBinaryWith is like Decomposition.UnmarshalBinary
+// but the decomposition is read according to the options.
+// The base of the options is ignored since the encoding holds the base.
+func UnmarshalBinaryWith(data []byte, opts ParseOptions) (Decomposition, error) {
+	if len(data) == 0 {
+		return Decomposition{}, errTruncated
+	}
+	if data[0] != binaryVersion {
+		return Decomposition{}, fmt.Errorf("unsupported binary encoding version %v", data[0])
+	}
+
+	dec := binaryDecoder{data: data[1:], limits: opts.limits(), lenient: opts.Lenient}
+	b, err := dec.bigInt()
+	if err != nil {
+		return Decomposition{}, err
+	}
+	if b.Sign() != 0 && b.Cmp(big.NewInt(2)) < 0 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
+	}
+	monomes, err := dec.terms(b, 0)
+	if err != nil {
+		return Decomposition{}, err
+	}
+	if len(dec.data) != 0 {
+		return Decomposition{}, fmt.Errorf("%v unexpected bytes after binary decomposition", len(dec.data))
+	}
+
+	// the default value of Decomposition has no base
+	if b.Sign() == 0 {
+		if len(monomes) != 0 {
+			return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
+		}
+		return Decomposition{}, nil
+	}
+
+	d := newDecomposition(b, monomes)
+	if opts.Lenient {
+		// carries may have enlarged the decomposition
+		if err := dec.limits.Check(d); err != nil {
+			return Decomposition{}, err
+		}
+	}
+	return d, nil
+}
+
+// binaryDecoder decodes binary decompositions.
+// It checks the limits while decoding, so that adversarial data
+// cannot exhaust memory or overflow the stack.
+type binaryDecoder struct {
+	data    []byte
+	limits  Limits
+	lenient bool // if true, terms are normalized instead of validated
+	size    int  // number of decoded monomes
+}
+
+// uvarint decodes an unsigned varint.
+func (dec *binaryDecoder) uvarint() (uint64, error) {
+	n, read := binary.Uvarint(dec.data)
+	if read <= 0 {
+		return 0, errTruncated
+	}
+	dec.data = dec.data[read:]
+	return n, nil
+}
+
+// bigInt decodes a non negative integer.
+// It returns an error wrapping ErrTooLarge if it has more bits than allowed by the limits.
+func (dec *binaryDecoder) bigInt() (*big.Int, error) {
+	n, err := dec.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(dec.data)) {
+		return nil, errTruncated
+	}
+	if max := dec.limits.MaxBits; max > 0 && n > uint64(max+7)/8 {
+		return nil, fmt.Errorf("%w: number exceeds %v bits", ErrTooLarge, max)
+	}
+	i := new(big.Int).SetBytes(dec.data[:n])
+	if max := dec.limits.MaxBits; max > 0 && i.BitLen() > max {
+		return nil, fmt.Errorf("%w: number exceeds %v bits", ErrTooLarge, max)
+	}
+	dec.data = dec.data[n:]
+	return i, nil
+}
+
+// terms decodes valid base-b terms nested at the given depth,
+// or normalizes them in lenient mode.
+func (dec *binaryDecoder) terms(b *big.Int, depth int) (terms, error) {
+	n, err := dec.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	// check limits before allocating anything:
+	// each monome takes at least two bytes
+	if dec.limits.MaxDepth > 0 && depth >= dec.limits.MaxDepth {
+		return nil, fmt.Errorf("%w: depth exceeds %v", ErrTooDeep, dec.limits.MaxDepth)
+	}
+	if n > uint64(len(dec.data)/2) {
+		return nil, errTruncated
+	}
+	dec.size += int(n)
+	if dec.limits.MaxSize > 0 && dec.size > dec.limits.MaxSize {
+		return nil, fmt.Errorf("%w: size exceeds %v", ErrTooLarge, dec.limits.MaxSize)
+	}
+
+	t := make(terms, n)
+	for i := range t {
+		if t[i].coeff, err = dec.bigInt(); err != nil {
+			return nil, err
+		}
+		if !dec.lenient && (t[i].coeff.Sign() == 0 || t[i].coeff.Cmp(b) >= 0) {
+			return nil, fmt.Errorf("%w: coefficient %v is not in [1, %v)", ErrInvalidDecomposition, t[i].coeff, b)
+		}
+		if t[i].exponent, err = dec.terms(b, depth+1); err != nil {
+			return nil, err
+		}
+		if !dec.lenient && i > 0 && t[i-1].exponent.cmp(t[i].exponent) >= 0 {
+			return nil, fmt.Errorf("%w: monomes are not sorted by increasing exponents", ErrInvalidDecomposition)
+		}
+	}
+	if dec.lenient {
+		bd := Builder{base: b, monomes: t}
+		return bd.normalize()
+	}
+	return t, nil
+}
@@ -0,0 +1,60 @@
+package decomposition
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func ExampleDecomposition_MarshalBinary() {
+	// 2 ^ (2) + 1
+	d, _ := New(2, 5)
+	b, _ := d.MarshalBinary()
+	fmt.Printf("% x\n", b)
+
+	// Output:
+	// 01 01 02 02 01 01 00 01 01 01 01 01 01 01 01 00
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	for i := 0; i < 100; i++ {
+		d := Rand(2+i%300, 3, rng)
+		b, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("cannot marshal %q: %v", d, err)
+		}
+		var decoded Decomposition
+		if err := decoded.UnmarshalBinary(b); err != nil {
+			t.Fatalf("cannot unmarshal % x: %v", b, err)
+		}
+		if !decoded.Equal(d) {
+			t.Errorf("%q became %q", d, decoded)
+		}
+	}
+
+	// default value
+	var decoded Decomposition
+	b, _ := Decomposition{}.MarshalBinary()
+	if err := decoded.UnmarshalBinary(b); err != nil || !decoded.Equal(Decomposition{}) {
+		t.Errorf("default value became %q (base %v): %v", decoded, decoded.Base(), err)
+	}
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	for _, b := range [][]byte{
+		{},                                // empty
+		{2, 1, 2, 0},                      // unknown version
+		{1, 1, 2},                         // truncated
+		{1, 1, 1, 1, 1, 1, 0},             // base too small
+		{1, 1, 2, 1, 1, 2, 0},             // coefficient too large
+		{1, 1, 3, 2, 1, 1, 0, 1, 2, 0},    // same exponent
+		{1, 1, 2, 0, 0},                   // trailing bytes
+		{1, 1, 2, 0xff, 0xff, 0xff, 0x0f}, // too many monomes
+	} {
+		var d Decomposition
+		if err := d.UnmarshalBinary(b); err == nil {
+			t.Errorf("invalid % x decoded as %q", b, d)
+		}
+	}
+}
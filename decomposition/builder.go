@@ -0,0 +1,210 @@
+package decomposition
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Builder builds a hereditary base-b decomposition monome by monome.
+// Unlike New, it does not require the value of the decomposition,
+// which may be far too large to be computed.
+type Builder struct {
+	base      *big.Int
+	monomes   terms
+	exponents []Decomposition // exponents of the monomes, with their base
+	limits    Limits
+	lenient   bool
+}
+
+// NewBuilder returns a Builder of hereditary base-b decompositions.
+// Built decompositions must not exceed DefaultLimits.
+func NewBuilder(b int) *Builder {
+	return &Builder{base: big.NewInt(int64(b)), limits: DefaultLimits()}
+}
+
+// NewBuilderBig is like NewBuilder but the base is a *big.Int.
+// The base is copied so it can be modified afterwards.
+func NewBuilderBig(b *big.Int) *Builder {
+	return &Builder{base: new(big.Int).Set(b), limits: DefaultLimits()}
+}
+
+// WithLimits sets the limits the built decomposition must not exceed.
+// It returns the builder so that calls can be chained.
+func (bd *Builder) WithLimits(l Limits) *Builder {
+	bd.limits = l
+	return bd
+}
+
+// Lenient makes the builder normalize the added monomes
+// instead of rejecting them: zero coefficients are ignored,
+// monomes sharing the same exponent are summed and
+// coefficients greater than or equal to the base are carried over.
+// It returns the builder so that calls can be chained.
+func (bd *Builder) Lenient() *Builder {
+	bd.lenient = true
+	return bd
+}
+
+// Add adds the monome 'coeff * b ^ exponent' to the decomposition being built.
+// Monomes can be added in any order.
+// It returns the builder so that calls can be chained.
+func (bd *Builder) Add(coeff int, exponent Decomposition) *Builder {
+	return bd.addMonome(big.NewInt(int64(coeff)), exponent)
+}
+
+// AddBig is like Add but the coefficient is a *big.Int,
+// which is needed in bases beyond int.
+// The coefficient is copied so it can be modified afterwards.
+func (bd *Builder) AddBig(coeff *big.Int, exponent Decomposition) *Builder {
+	return bd.addMonome(new(big.Int).Set(coeff), exponent)
+}
+
+// addMonome adds the monome 'coeff * b ^ exponent' whose coefficient is owned by the builder.
+func (bd *Builder) addMonome(coeff *big.Int, exponent Decomposition) *Builder {
+	bd.exponents = append(bd.exponents, exponent)
+	bd.monomes = append(bd.monomes, monome{
+		coeff:    coeff,
+		exponent: exponent.monomes,
+	})
+	return bd
+}
+
+// Build returns the decomposition made of all added monomes.
+// It returns an error if the base is lower than 2,
+// if a coefficient is not in [1, b), if an exponent is not a base-b decomposition
+// or if several monomes share the same exponent.
+// In lenient mode, only negative coefficients and exponents in another base are rejected.
+// It returns an error wrapping ErrTooDeep or ErrTooLarge if the limits are exceeded.
+func (bd *Builder) Build() (Decomposition, error) {
+	// base must at least 2
+	if bd.base.Cmp(big.NewInt(2)) < 0 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, bd.base)
+	}
+
+	// exponents must be in the same base
+	for _, exp := range bd.exponents {
+		if !exp.IsZero() && exp.base.Cmp(bd.base) != 0 {
+			return Decomposition{}, fmt.Errorf("%w: exponent %q is not a base-%v decomposition", ErrBaseMismatch, exp, bd.base)
+		}
+	}
+
+	// strictly validate or normalize monomes
+	var (
+		monomes terms
+		err     error
+	)
+	if bd.lenient {
+		monomes, err = bd.normalize()
+	} else {
+		monomes, err = bd.validate()
+	}
+	if err != nil {
+		return Decomposition{}, err
+	}
+
+	// check limits
+	d := newDecomposition(bd.base, monomes)
+	if err := bd.limits.Check(d); err != nil {
+		return Decomposition{}, err
+	}
+
+	return d, nil
+}
+
+// validate returns the added monomes sorted from least to most significant.
+// It returns an error if they do not form a valid decomposition.
+func (bd *Builder) validate() (terms, error) {
+	// check coefficients
+	for _, m := range bd.monomes {
+		if m.coeff.Sign() < 1 || m.coeff.Cmp(bd.base) >= 0 {
+			return nil, fmt.Errorf("%w: coefficient %v is not in [1, %v)", ErrInvalidDecomposition, m.coeff, bd.base)
+		}
+	}
+
+	// sort monomes from least to most significant
+	monomes := make(terms, len(bd.monomes))
+	copy(monomes, bd.monomes)
+	sort.Slice(monomes, func(i, j int) bool { return monomes[i].exponent.cmp(monomes[j].exponent) < 0 })
+
+	// exponents must be unique
+	for i := 1; i < len(monomes); i++ {
+		if monomes[i].exponent.cmp(monomes[i-1].exponent) == 0 {
+			return nil, fmt.Errorf("%w: exponent %q appears several times", ErrInvalidDecomposition, newDecomposition(bd.base, monomes[i].exponent))
+		}
+	}
+
+	return monomes, nil
+}
+
+// normalize returns the sum of the added monomes as a valid decomposition.
+// It returns an error if a coefficient is negative.
+func (bd *Builder) normalize() (terms, error) {
+	var sum terms
+	for _, m := range bd.monomes {
+		if m.coeff.Sign() < 0 {
+			return nil, fmt.Errorf("%w: coefficient %v", ErrNegative, m.coeff)
+		}
+		if m.coeff.Sign() == 0 {
+			continue
+		}
+		sum = sum.addMonome(bd.base, m.coeff, m.exponent)
+	}
+	return sum, nil
+}
+
+// MustBuild is like Build but panics if the decomposition is invalid.
+func (bd *Builder) MustBuild() Decomposition {
+	d, err := bd.Build()
+	if err != nil {
+		panic("decomposition: " + err.Error())
+	}
+	return d
+}
+
+// GoString returns a Go expression building the decomposition with the Builder API.
+// It is used by the %#v verb so that a decomposition printed at runtime
+// can be pasted into a test.
+// Bases and coefficients beyond int32 are built with the *big.Int variants of the API.
+func (d Decomposition) GoString() string {
+	if d.base == nil {
+		return "decomposition.Decomposition{}"
+	}
+
+	// add monomes from the most significant to the least significant one
+	var b strings.Builder
+	if isSmall(d.base) {
+		fmt.Fprintf(&b, "decomposition.NewBuilder(%v)", d.base)
+	} else {
+		fmt.Fprintf(&b, "decomposition.NewBuilderBig(%v)", goBigInt(d.base))
+	}
+	for i := len(d.monomes) - 1; i >= 0; i-- {
+		m := d.monomes[i]
+		exp := "decomposition.Decomposition{}" // base is useless for a zero exponent
+		if !m.exponent.isZero() {
+			exp = newDecomposition(d.base, m.exponent).GoString()
+		}
+		if isSmall(m.coeff) {
+			fmt.Fprintf(&b, ".Add(%v, %v)", m.coeff, exp)
+		} else {
+			fmt.Fprintf(&b, ".AddBig(%v, %v)", goBigInt(m.coeff), exp)
+		}
+	}
+	b.WriteString(".MustBuild()")
+	return b.String()
+}
+
+// isSmall returns true if n fits in an int on every platform.
+func isSmall(n *big.Int) bool {
+	return n.IsInt64() && n.Int64() <= math.MaxInt32
+}
+
+// goBigInt returns a Go expression of type *big.Int whose value is n.
+func goBigInt(n *big.Int) string {
+	if n.IsInt64() {
+		return fmt.Sprintf("big.NewInt(%v)", n)
+	}
+	return fmt.Sprintf(`func() *big.Int { n, _ := new(big.Int).SetString("%v", 10); return n }()`, n)
+}
@@ -0,0 +1,97 @@
+package decomposition
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func ExampleDecomposition_GoString() {
+	d, _ := New(2, 10)
+	fmt.Printf("%#v\n", d)
+
+	// Output:
+	// decomposition.NewBuilder(2).Add(1, decomposition.NewBuilder(2).Add(1, decomposition.NewBuilder(2).Add(1, decomposition.Decomposition{}).MustBuild()).Add(1, decomposition.Decomposition{}).MustBuild()).Add(1, decomposition.NewBuilder(2).Add(1, decomposition.Decomposition{}).MustBuild()).MustBuild()
+}
+
+func TestBuilder(t *testing.T) {
+	one := NewBuilder(3).Add(1, Decomposition{}).MustBuild()
+	two := NewBuilder(3).Add(2, Decomposition{}).MustBuild()
+
+	// 2 * 3 ^ 2 + 3 + 2, monomes added in any order
+	d, err := NewBuilder(3).Add(1, one).Add(2, Decomposition{}).Add(2, two).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, _ := New(3, 23)
+	if d.String() != expected.String() {
+		t.Errorf("built %q, expecting %q", d, expected)
+	}
+}
+
+func TestBuilderInvalid(t *testing.T) {
+	one := NewBuilder(3).Add(1, Decomposition{}).MustBuild()
+	for _, bd := range []*Builder{
+		NewBuilder(1),                         // base too small
+		NewBuilder(3).Add(0, Decomposition{}), // zero coefficient
+		NewBuilder(3).Add(3, Decomposition{}), // coefficient too large
+		NewBuilder(2).Add(1, one),             // exponent in another base
+		NewBuilder(3).Add(1, one).Add(2, one), // duplicate exponent
+	} {
+		if d, err := bd.Build(); err == nil {
+			t.Errorf("invalid decomposition %q built without error", d)
+		}
+	}
+}
+
+func TestBuilderBig(t *testing.T) {
+	// base and coefficients beyond int64
+	b, _ := new(big.Int).SetString("100000000000000000000", 10)
+	coeff := new(big.Int).Sub(b, big.NewInt(1))
+	one := NewBuilderBig(b).Add(1, Decomposition{}).MustBuild()
+	d, err := NewBuilderBig(b).AddBig(coeff, one).AddBig(coeff, Decomposition{}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// b * b - 1
+	expected := new(big.Int).Mul(b, b)
+	expected.Sub(expected, big.NewInt(1))
+	if v := d.Eval(); v.Cmp(expected) != 0 {
+		t.Errorf("%q has value %v, expecting %v", d, v, expected)
+	}
+
+	// the Go expression and the string can be read back
+	if s := fmt.Sprintf("%#v", d); !strings.Contains(s, "NewBuilderBig(") || !strings.Contains(s, ".AddBig(") {
+		t.Errorf("wrong Go expression %v", s)
+	}
+	parsed, err := Parse(d.String())
+	if err != nil || parsed.Base().Cmp(b) != 0 || parsed.String() != d.String() {
+		t.Errorf("%q parsed as %q (%v)", d, parsed, err)
+	}
+
+	// coefficient must be lower than the base
+	if _, err := NewBuilderBig(b).AddBig(b, Decomposition{}).Build(); err == nil {
+		t.Error("coefficient equal to the base accepted")
+	}
+}
+
+func TestBuilderLenient(t *testing.T) {
+	one := NewBuilder(3).Add(1, Decomposition{}).MustBuild()
+
+	// 2 * 3 + 2 * 3 + 0 + 5 = 17 = 3 ^ 2 + 2 * 3 + 2
+	d, err := NewBuilder(3).Lenient().Add(2, one).Add(2, one).Add(0, one).Add(5, Decomposition{}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, _ := New(3, 17)
+	if d.String() != expected.String() {
+		t.Errorf("built %q, expecting %q", d, expected)
+	}
+
+	// negative coefficients are rejected anyway
+	if _, err := NewBuilder(3).Lenient().Add(-1, one).Build(); err == nil {
+		t.Errorf("negative coefficient accepted")
+	}
+}
@@ -0,0 +1,74 @@
+package decomposition
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Compact returns the decomposition in compact notation, e.g. "2^(2^1+1)+2^1",
+// intended for logs, URLs and command-line arguments.
+// There are no spaces and the base is always written with its exponent,
+// even when it is 1, so that the base can be read from any monome
+// and bare numbers are always constants.
+// Exponents are grouped with parentheses unless they are constants.
+// Compact notation is parsed by ParseCompact.
+func (d Decomposition) Compact() string {
+	var sb strings.Builder
+	writeCompact(&sb, d.base.String(), d.monomes)
+	return sb.String()
+}
+
+// writeCompact writes base-b terms in compact notation, most significant monomes first.
+func writeCompact(sb *strings.Builder, b string, t terms) {
+	if t.isZero() {
+		sb.WriteString("0")
+		return
+	}
+	for i := len(t) - 1; i >= 0; i-- {
+		m := t[i]
+		switch {
+		case m.exponent.isZero():
+			writeInt(sb, m.coeff)
+		default:
+			if m.coeff.Cmp(bigOne) != 0 {
+				writeInt(sb, m.coeff)
+				sb.WriteString("*")
+			}
+			sb.WriteString(b)
+			sb.WriteString("^")
+			if len(m.exponent) == 1 && m.exponent[0].exponent.isZero() {
+				writeInt(sb, m.exponent[0].coeff)
+			} else {
+				sb.WriteString("(")
+				writeCompact(sb, b, m.exponent)
+				sb.WriteString(")")
+			}
+		}
+		if i > 0 {
+			sb.WriteString("+")
+		}
+	}
+}
+
+// ParseCompact parses a decomposition in compact notation, as returned by Compact.
+// The base is read from the expression; a constant (or zero) has no explicit base:
+// it is parsed in the smallest base in which it is a constant.
+func ParseCompact(s string) (Decomposition, error) {
+	return parse(s, sum.compactBase, ParseOptions{})
+}
+
+// compactBase returns the base of a sum in compact notation.
+func (s sum) compactBase() *big.Int {
+	if b := s.explicitBase(); b != nil {
+		return b
+	}
+
+	// bare numbers are constants
+	b := big.NewInt(2)
+	for _, t := range s {
+		if t.number != nil && t.number.Cmp(b) >= 0 {
+			b = new(big.Int).Add(t.number, bigOne)
+		}
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+package decomposition
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleDecomposition_Compact() {
+	d, _ := New(2, 10)
+	fmt.Println(d.Compact())
+
+	d, _ = New(3, 2*81+3+2)
+	fmt.Println(d.Compact())
+
+	// Output:
+	// 2^(2^1+1)+2^1
+	// 2*3^(3^1+1)+3^1+2
+}
+
+func TestCompactRoundTrip(t *testing.T) {
+	for b := 2; b < 6; b++ {
+		for n := 0; n < 500; n++ {
+			d, _ := New(b, n)
+			s := d.Compact()
+			parsed, err := ParseCompact(s)
+			if err != nil {
+				t.Errorf("base-%v decomposition of %v: cannot parse %q: %v", b, n, s, err)
+				continue
+			}
+
+			// constants are parsed in the smallest possible base
+			if parsed.Eval().Int64() != int64(n) || (n >= b && !parsed.Equal(d)) {
+				t.Errorf("base-%v decomposition of %v: %q parsed as %v", b, n, s, parsed)
+			}
+		}
+	}
+}
+
+func TestParseCompactConstant(t *testing.T) {
+	for _, g := range []struct {
+		s    string
+		base int64
+	}{
+		{"0", 2},
+		{"1", 2},
+		{"2", 3},
+		{"9", 10},
+	} {
+		d, err := ParseCompact(g.s)
+		if err != nil || d.Base().Int64() != g.base {
+			t.Errorf("%q: expecting base %v, got %v (%v)", g.s, g.base, d.Base(), err)
+		}
+	}
+}
@@ -3,15 +3,41 @@ package decomposition
 import (
 	"fmt"
 	"math/big"
-	"strconv"
-	"strings"
+	"sync"
 )
 
+// bigOne is the constant 1, it must not be modified.
+var bigOne = big.NewInt(1)
+
 // Decomposition is a hereditary base-b decomposition.
 type Decomposition struct {
+	// base of the decomposition and of all its nested exponents:
+	// it is stored once here rather than in each monome.
+	// It is nil for the default value of Decomposition.
+	// Like coefficients, it is never modified once stored
+	// so that it can be shared between decompositions.
+	base *big.Int
+
 	// order of the monomes matter:
 	// they are sorted from least to most significant
-	monomes []monome
+	monomes terms
+
+	// value of the decomposition, computed by the first call to Eval.
+	// It is shared by all copies of the decomposition
+	// and nil for the default value of Decomposition, which is zero anyway.
+	value *lazyValue
+}
+
+// lazyValue is the value of a decomposition, computed once.
+type lazyValue struct {
+	once  sync.Once
+	value *big.Int
+}
+
+// newDecomposition returns the base-b decomposition made of the terms,
+// with its own (not yet computed) value.
+func newDecomposition(b *big.Int, t terms) Decomposition {
+	return Decomposition{b, t, new(lazyValue)}
 }
 
 // New returns the hereditary base-b decomposition of n.
@@ -19,96 +45,203 @@ type Decomposition struct {
 func New(b, n int) (Decomposition, error) {
 	// n must be non negative
 	if n < 0 {
-		return Decomposition{}, fmt.Errorf("n must be non negative")
+		return Decomposition{}, fmt.Errorf("%w: n = %v", ErrNegative, n)
 	}
 
 	// base must at least 2
 	if b < 2 {
-		return Decomposition{}, fmt.Errorf("base must be at least 2")
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
 	}
 
-	return Decomposition{recDecompose(b, n, 0)}.clean(), nil
+	return newDecomposition(big.NewInt(int64(b)), decompose(b, n)), nil
 }
 
-// recDecompose recursively builds the hereditary base-b decomposition of n.
-// Monomes are sorted from the least significant to the most significant one.
-func recDecompose(b, n, k int) []monome {
-	// stop condition: nothing to decompose, return the empty Decomposition
-	if n == 0 {
-		return nil
+// NewBig is like New but n is a *big.Int,
+// so that numbers which do not fit in an int can be decomposed.
+// n must be non negative and b must be at least 2.
+func NewBig(b int, n *big.Int) (Decomposition, error) {
+	// n must be non negative
+	if n.Sign() < 0 {
+		return Decomposition{}, fmt.Errorf("%w: n = %v", ErrNegative, n)
 	}
 
-	// init decomposition with its least significant monome
-	singleton := []monome{
-		monome{
-			coeff:    n % b,
-			base:     b,
-			exponent: Decomposition{recDecompose(b, k, 0)},
-		},
+	// base must at least 2
+	if b < 2 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
 	}
-	return append(singleton, recDecompose(b, n/b, k+1)...)
+
+	// base-b digits of n, from the least significant one:
+	// the number of digits fits in an int, so exponents are decomposed with decompose
+	var (
+		monomes terms
+		base    = big.NewInt(int64(b))
+		q       = new(big.Int).Set(n)
+		r       = new(big.Int)
+	)
+	for k := 0; q.Sign() > 0; k++ {
+		q.QuoRem(q, base, r)
+		if r.Sign() == 0 {
+			continue
+		}
+		monomes = append(monomes, monome{
+			coeff:    new(big.Int).Set(r),
+			exponent: decompose(b, k),
+		})
+	}
+	return newDecomposition(base, monomes), nil
 }
 
-// copyDecomposition returns a deep copy of the decomposition.
-func copyDecomposition(d Decomposition) Decomposition {
-	copied := make([]monome, len(d.monomes))
-	for i, m := range d.monomes {
-		copied[i] = copyMonome(m)
+// NewPower returns the hereditary base-b decomposition of b ^ e
+// without evaluating it, so that huge values can be decomposed.
+// e must be non negative and b must be at least 2.
+func NewPower(b, e int) (Decomposition, error) {
+	// e must be non negative
+	if e < 0 {
+		return Decomposition{}, fmt.Errorf("%w: e = %v", ErrNegative, e)
+	}
+
+	// base must at least 2
+	if b < 2 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
+	}
+
+	return newDecomposition(big.NewInt(int64(b)), terms{{coeff: bigOne, exponent: decompose(b, e)}}), nil
+}
+
+// NewTower returns the hereditary base-b decomposition of the tower b ^ b ^ ... ^ b
+// of the given height without evaluating it: the tower of height 0 is 1,
+// the one of height 1 is b, the one of height 2 is b ^ b, etc.
+// height must be non negative and b must be at least 2.
+func NewTower(b, height int) (Decomposition, error) {
+	// height must be non negative
+	if height < 0 {
+		return Decomposition{}, fmt.Errorf("%w: height = %v", ErrNegative, height)
+	}
+
+	// base must at least 2
+	if b < 2 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
+	}
+
+	// start from 1 and raise b to it, height times
+	tower := terms{{coeff: bigOne}}
+	for i := 0; i < height; i++ {
+		tower = terms{{coeff: bigOne, exponent: tower}}
+	}
+	return newDecomposition(big.NewInt(int64(b)), tower), nil
+}
+
+// decompose builds the hereditary base-b decomposition of n.
+// Monomes are sorted from the least significant to the most significant one
+// and zero digits are skipped, so the terms are clean.
+// Digits are computed by a loop: only exponents are decomposed recursively,
+// and since the exponent of a digit of an int is lower than 64,
+// the recursion is at most a few levels deep whatever n.
+func decompose(b, n int) terms {
+	var t terms
+	for k := 0; n > 0; k, n = k+1, n/b {
+		if r := n % b; r != 0 {
+			t = append(t, monome{
+				coeff:    big.NewInt(int64(r)),
+				exponent: decompose(b, k),
+			})
+		}
 	}
-	return Decomposition{copied}
+	return t
 }
 
 // IsZero returns true if the decomposition is the decomposition of 0 (in any base).
 // The default value of Decomposition is a zero decomposition.
 func (d Decomposition) IsZero() bool {
-	return len(d.monomes) == 0
+	return d.monomes.isZero()
 }
 
-// isOne returns true if the decomposition is the decomposition of 1 (in any base).
-// This applies only to a cleaned decomposition.
-func (d Decomposition) isOne() bool {
-	return len(d.monomes) == 1 && d.monomes[0].isOne()
+// Base returns the base of the decomposition.
+// It is a *big.Int since the base grows by one at each step of a Goodstein sequence,
+// without any bound; the returned value is a copy which can be freely modified.
+// It returns 0 for the default value of Decomposition
+// since it is a zero decomposition in no particular base.
+func (d Decomposition) Base() *big.Int {
+	if d.base == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.base)
 }
 
-// clean removes all zero-monomes from the Decomposition.
-func (d Decomposition) clean() Decomposition {
-	var cleaned []monome
-	for _, m := range d.monomes {
-		// remove zero monome
-		if m.isZero() {
-			continue
+// Validate returns an error if the decomposition breaks an invariant:
+// its base must be at least 2, coefficients must be in [1, base)
+// and the exponents of the monomes of each sum must be strictly increasing
+// from the least to the most significant monome, at all levels.
+// Since the base is stored once, it is consistent throughout the decomposition.
+// The default value of Decomposition is valid.
+// Decompositions returned by the package are always valid,
+// but decompositions built from corrupted data by other means may not be.
+func (d Decomposition) Validate() error {
+	if d.base == nil {
+		if !d.IsZero() {
+			return fmt.Errorf("%w: no base", ErrInvalidDecomposition)
 		}
-
-		// non zero monome:
-		// clean its exponent and add it to the cleaned Decomposition
-		cleaned = append(cleaned, monome{
-			coeff:    m.coeff,
-			base:     m.base,
-			exponent: m.exponent.clean(),
-		})
+		return nil
+	}
+	if d.base.Cmp(big.NewInt(2)) < 0 {
+		return fmt.Errorf("%w: %v", ErrBaseTooSmall, d.base)
 	}
+	return d.monomes.validate(d.base)
+}
 
-	return Decomposition{cleaned}
+// validate returns an error if base-b terms break an invariant.
+func (t terms) validate(b *big.Int) error {
+	for i, m := range t {
+		if m.coeff == nil || m.coeff.Sign() < 1 || m.coeff.Cmp(b) >= 0 {
+			return fmt.Errorf("%w: coefficient %v is not in [1, %v)", ErrInvalidDecomposition, m.coeff, b)
+		}
+		if i > 0 && t[i-1].exponent.cmp(m.exponent) >= 0 {
+			return fmt.Errorf("%w: exponents %q and %q are not increasing", ErrInvalidDecomposition, newDecomposition(b, t[i-1].exponent), newDecomposition(b, m.exponent))
+		}
+		if err := m.exponent.validate(b); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// string is a helper for the String and LaTeX methods.
-// It returns a human-readable decomposition with
-// the given symbols for multiplication and left and right
-// groupers around the exponents.
-func (d Decomposition) string(times, leftGroup, rightGroup string) string {
-	// length of the decomposition
-	l := len(d.monomes)
-	// if there is no monome, decompostion is zero
-	if l == 0 {
-		return "0"
+// Equal returns true if both decompositions have the same base
+// and the same monomes, exponents being compared recursively.
+// It does not evaluate the decompositions.
+// The default value of Decomposition is only equal to itself
+// since it has no base.
+func (d Decomposition) Equal(other Decomposition) bool {
+	switch {
+	case d.base == nil || other.base == nil:
+		return d.base == other.base && d.IsZero() && other.IsZero()
+	case d.base.Cmp(other.base) != 0:
+		return false
+	default:
+		return d.monomes.cmp(other.monomes) == 0
 	}
+}
 
-	// write all monomes in reverse order
-	strMonomes := make([]string, l)
-	for i, m := range d.monomes {
-		strMonomes[l-1-i] = m.string(times, leftGroup, rightGroup)
+// Cmp compares the values of two decompositions with the same base.
+// It returns -1, 0 or +1 depending on whether d is lower, equal
+// or greater than other.
+// Most significant monomes are compared first, their exponents being compared recursively,
+// so it takes time proportional to the size of the decompositions
+// rather than to the number of digits of their values.
+// It panics if the bases differ, unless one of the decompositions is zero.
+func (d Decomposition) Cmp(other Decomposition) int {
+	if !d.IsZero() && !other.IsZero() && d.base.Cmp(other.base) != 0 {
+		panic(fmt.Sprintf("decomposition: comparison of base-%v and base-%v decompositions", d.base, other.base))
 	}
-	return strings.Join(strMonomes, " + ")
+	return d.monomes.cmp(other.monomes)
+}
+
+// SameShape returns true if both decompositions have the same monomes
+// except for their constant monome (the one with a zero exponent), if any.
+// Bases are not compared.
+// A Goodstein sequence keeps the same shape as long as it only decrements
+// its constant monome, a stretch which can be computed without iterating.
+func (d Decomposition) SameShape(other Decomposition) bool {
+	return d.monomes.withoutConstant().cmp(other.monomes.withoutConstant()) == 0
 }
 
 // String returns a human readable decomposition
@@ -116,40 +249,104 @@ func (d Decomposition) string(times, leftGroup, rightGroup string) string {
 // and least significant ones on the right.
 // The decomposition does not contain any spurrious '0+...', '0*...' expressions.
 func (d Decomposition) String() string {
-	return d.string("*", "(", ")")
+	return plainFormatter.format(d.base, d.monomes)
 }
 
 // LaTeX is similar to String but it returns a valid LaTeX command.
 // Special characters are not escaped so it must not be formatted with the %s verb.
 // Instead, the %q one must be used.
 func (d Decomposition) LaTeX() string {
-	return d.string("\times", "{", "}")
+	return latexFormatter.format(d.base, d.monomes)
+}
+
+// BreakableLaTeX is similar to LaTeX but it allows line breaks
+// after top-level plus signs, so that very long decompositions
+// do not overflow into the margin.
+func (d Decomposition) BreakableLaTeX() string {
+	f := latexFormatter
+	f.plus = ` + \allowbreak `
+	return f.format(d.base, d.monomes)
+}
+
+// Typst is similar to LaTeX but it returns Typst math markup,
+// e.g. "2 times 3^(3 + 1) + 2".
+// Typst drops the parentheses around exponents.
+func (d Decomposition) Typst() string {
+	return typstFormatter.format(d.base, d.monomes)
 }
 
 // Eval computes and returns the value of the decomposition.
 // It returns a *big.Int since huge numbers are expected.
-// Note that even if the value of the expression may be huge,
-// integer literals in it should remain small enough for type int.
+// The value is only computed once and cached in the decomposition (and its copies),
+// so that repeated calls are cheap; the returned value is a copy which can be freely modified.
 func (d Decomposition) Eval() *big.Int {
-	result := big.NewInt(0)
-	for _, m := range d.monomes {
-		result.Add(result, m.eval())
+	if d.value == nil {
+		return d.monomes.eval(d.base)
 	}
-	return result
+	d.value.once.Do(func() {
+		d.value.value = d.monomes.eval(d.base)
+	})
+	return new(big.Int).Set(d.value.value)
 }
 
 // IncrementBase returns a new Decomposition with base incremented by one.
 // Original decomposition is left unchanged.
+// Since the base is stored once, this does not depend on the size of the decomposition:
+// monomes are shared between both decompositions.
 func (d Decomposition) IncrementBase() Decomposition {
-	incremented := make([]monome, len(d.monomes))
-	for i, m := range d.monomes {
-		incremented[i] = monome{
-			coeff:    m.coeff,
-			base:     m.base + 1,
-			exponent: m.exponent.IncrementBase(),
-		}
+	// the default value has no base to increment
+	if d.base == nil {
+		return d
+	}
+	return newDecomposition(new(big.Int).Add(d.base, bigOne), d.monomes)
+}
+
+// IncrementBaseBy is like IncrementBase but the base is incremented by k at once,
+// as in generalized Goodstein sequences where the base jumps by more than one.
+// Since the base is stored once for all monomes, it takes constant time whatever k.
+// k must be non negative, otherwise it panics: use WithBase to lower the base.
+func (d Decomposition) IncrementBaseBy(k int) Decomposition {
+	if k < 0 {
+		panic(fmt.Sprintf("decomposition: negative base increment %v", k))
+	}
+	// the default value has no base to increment
+	if d.base == nil {
+		return d
+	}
+	return newDecomposition(new(big.Int).Add(d.base, big.NewInt(int64(k))), d.monomes)
+}
+
+// WithBase returns the decomposition with base b instead of its base,
+// throughout the nested exponents, without re-decomposing its value.
+// It generalizes IncrementBase to arbitrary base bumping rules.
+// b must be at least 2 and greater than all coefficients.
+func (d Decomposition) WithBase(b int) (Decomposition, error) {
+	return d.WithBaseBig(big.NewInt(int64(b)))
+}
+
+// WithBaseBig is like WithBase but the base is a *big.Int,
+// e.g. a base read from a long running sequence.
+// b is not modified, nor retained.
+func (d Decomposition) WithBaseBig(b *big.Int) (Decomposition, error) {
+	// base must at least 2
+	if b.Cmp(bigOne) <= 0 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
+	}
+
+	// and all coefficients must remain digits
+	if max := d.monomes.maxCoeff(); max != nil && max.Cmp(b) >= 0 {
+		return Decomposition{}, fmt.Errorf("%w: coefficient %v is not lower than base %v", ErrInvalidDecomposition, max, b)
 	}
-	return Decomposition{incremented}
+	return newDecomposition(new(big.Int).Set(b), d.monomes), nil
+}
+
+// Rebase returns the hereditary base-b decomposition of the value of the decomposition.
+// Unlike WithBase, the value is preserved: the decomposition is evaluated
+// and its value is decomposed again, which is only practical for small values.
+// It is mostly useful to check symbolic computations against numeric ones.
+// b must be at least 2.
+func (d Decomposition) Rebase(b int) (Decomposition, error) {
+	return NewBig(b, d.Eval())
 }
 
 // Decrement returns a new Decomposition
@@ -157,32 +354,183 @@ func (d Decomposition) IncrementBase() Decomposition {
 // If the decomposition is already equal to zero it returns the zero Decomposition.
 // The original decomposition is left unchanged.
 func (d Decomposition) Decrement() Decomposition {
+	// without any limit, decrement cannot fail
+	decremented, _ := d.monomes.decrement(d.base, 0)
+	return newDecomposition(d.base, decremented)
+}
+
+// Increment returns a new Decomposition whose value is one more than d,
+// the inverse of Decrement: the constant monome is incremented
+// and carries are propagated to the next exponents, e.g. (b-1) + 1 = b.
+// The default value of Decomposition has no base, so it panics.
+// The original decomposition is left unchanged.
+func (d Decomposition) Increment() Decomposition {
+	if d.base == nil {
+		panic("decomposition: increment of a decomposition without base")
+	}
+	return newDecomposition(d.base, d.monomes.addMonome(d.base, bigOne, nil))
+}
+
+// terms is a hereditary decomposition without its base:
+// the base is held by the enclosing Decomposition.
+// Order of the monomes matter:
+// they are sorted from least to most significant.
+// Terms are never modified once built: operations build new slices
+// for the monomes they change and share all other monomes,
+// with their exponents, between the original and the resulting terms.
+type terms []monome
+
+// isZero returns true if the terms are a decomposition of 0.
+func (t terms) isZero() bool {
+	return len(t) == 0
+}
+
+// isOne returns true if the terms are a decomposition of 1.
+// This applies only to cleaned terms.
+func (t terms) isOne() bool {
+	return len(t) == 1 && t[0].isOne()
+}
+
+// cmp compares two cleaned terms with the same base.
+// It returns -1, 0 or +1 depending on whether t is lower, equal
+// or greater than other.
+// Most significant monomes are compared first,
+// their exponents being compared recursively.
+func (t terms) cmp(other terms) int {
+	i, j := len(t)-1, len(other)-1
+	for ; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := t[i].cmp(other[j]); c != 0 {
+			return c
+		}
+	}
+
+	// one of them is a prefix of the other one
+	switch {
+	case i >= 0:
+		return 1
+	case j >= 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// withoutConstant returns the cleaned terms without their constant monome.
+// The terms are not copied.
+func (t terms) withoutConstant() terms {
+	if len(t) > 0 && t[0].exponent.isZero() {
+		return t[1:]
+	}
+	return t
+}
+
+// isClean returns true if there is no zero-monome in the terms
+// nor in their exponents.
+func (t terms) isClean() bool {
+	for _, m := range t {
+		if m.isZero() || !m.exponent.isClean() {
+			return false
+		}
+	}
+	return true
+}
+
+// clean removes all zero-monomes from the terms.
+// Terms which are already clean are returned as is, without any allocation.
+// Otherwise, clean monomes are reused and only the other ones are rebuilt.
+func (t terms) clean() terms {
+	// look for the first monome to be cleaned
+	i := 0
+	for i < len(t) && !t[i].isZero() && t[i].exponent.isClean() {
+		i++
+	}
+
+	// nothing to clean
+	if i == len(t) {
+		return t
+	}
+
+	// keep the clean prefix and clean the other monomes
+	cleaned := make(terms, i, len(t))
+	copy(cleaned, t[:i])
+	for _, m := range t[i:] {
+		// remove zero monome
+		if m.isZero() {
+			continue
+		}
+
+		// non zero monome:
+		// clean its exponent (if needed) and add it to the cleaned terms
+		if !m.exponent.isClean() {
+			m.exponent = m.exponent.clean()
+		}
+		cleaned = append(cleaned, m)
+	}
+
+	// an empty decomposition is always nil
+	if len(cleaned) == 0 {
+		return nil
+	}
+	return cleaned
+}
+
+// eval returns the value of the terms in base b.
+func (t terms) eval(b *big.Int) *big.Int {
+	result := big.NewInt(0)
+	for _, m := range t {
+		result.Add(result, m.eval(b))
+	}
+	return result
+}
+
+// decrement returns new terms which are
+// the base-b terms symbolically decremented.
+// If max is positive and the decremented terms would have more than max monomes
+// (including nested ones), it returns an error wrapping ErrTooLarge.
+// The original terms are left unchanged.
+func (t terms) decrement(b *big.Int, max int) (terms, error) {
 	// if decomposition is zero, return zero
-	if d.IsZero() {
-		return Decomposition{}
+	if t.isZero() {
+		return nil, nil
 	}
 
-	// to be decremented
-	decremented := copyDecomposition(d).monomes
+	// to be decremented: only the least significant monome changes,
+	// all other monomes are shared
+	decremented := make(terms, len(t))
+	copy(decremented, t)
+	size := 0
+	if max > 0 {
+		size = decremented.size()
+	}
 
 	// find the least significant monome
 	// and decrease its coefficient by one
-	decremented[0].coeff -= 1
+	decremented[0].coeff = new(big.Int).Sub(decremented[0].coeff, bigOne)
 
 	// prepend all monomes from this one to zero
-	// with coefficient (base-1).
+	// with coefficient (base-1), shared by all of them.
 	exp := decremented[0].exponent
-	var lsms []monome
-	for !exp.IsZero() {
+	maxCoeff := new(big.Int).Sub(b, bigOne)
+	var lsms terms
+	for !exp.isZero() {
 		// decrease exponent
-		exp = exp.Decrement()
+		var err error
+		exp, err = exp.decrement(b, max)
+		if err != nil {
+			return nil, err
+		}
+
+		// check the size before growing any further
+		size += 1 + exp.size()
+		if max > 0 && size > max {
+			return nil, fmt.Errorf("%w: decrement exceeds %v monomes", ErrTooLarge, max)
+		}
 
 		// new monome is the least significant one.
 		// prepend it
 		lsms = append(lsms, monome{
-			coeff:    decremented[0].base - 1,
-			base:     decremented[0].base,
-			exponent: copyDecomposition(exp),
+			coeff:    maxCoeff,
+			exponent: exp,
 		})
 	}
 
@@ -194,87 +542,41 @@ func (d Decomposition) Decrement() Decomposition {
 	decremented = append(lsms, decremented...)
 
 	// clean the decomposition
-	return Decomposition{decremented}.clean()
+	return decremented.clean(), nil
 }
 
 // monome is an expression of the form 'coeff * base ^ exponent'
-// where coeff and base are integers and exponent is a
-// hereditary base-b decomposition with base 'base'.
+// where coeff is an integer and exponent is a
+// hereditary decomposition in the same base.
+// The base is held by the enclosing Decomposition.
+// The coefficient is never modified once stored (a new one is allocated instead)
+// so that it can be shared between monomes.
 type monome struct {
-	coeff, base int
-	exponent    Decomposition
-}
-
-// copyMonome returns a deep copy (i.e. the exponent is also a copy)
-// of the original monome.
-func copyMonome(m monome) monome {
-	return monome{
-		coeff:    m.coeff,
-		base:     m.base,
-		exponent: copyDecomposition(m.exponent),
-	}
+	coeff    *big.Int
+	exponent terms
 }
 
 // isZero returns true if the monome is equal to zero.
-func (m monome) isZero() bool { return m.coeff == 0 }
+func (m monome) isZero() bool { return m.coeff.Sign() == 0 }
 
 // isOne returns true if the monome is equal to one.
 func (m monome) isOne() bool {
-	return m.coeff == 1 && m.exponent.IsZero()
+	return m.coeff.Cmp(bigOne) == 0 && m.exponent.isZero()
 }
 
-// string is a helper for the String and LaTeX methods.
-// It returns a human readable version of the monome
-// with given symbols for the multiplication and
-// left and right 'groupers' around the exponent.
-func (m monome) string(times, leftGroup, rightGroup string) string {
-	// if monome is zero, just return 0
-	if m.isZero() {
-		return "0"
+// cmp compares two non zero monomes with the same base.
+// The exponents are compared first, then the coefficients.
+func (m monome) cmp(other monome) int {
+	if c := m.exponent.cmp(other.exponent); c != 0 {
+		return c
 	}
-
-	// elementary blocks
-	strCoeff := strconv.FormatInt(int64(m.coeff), 10)
-	strBase := strconv.FormatInt(int64(m.base), 10)
-	times = " " + times + " "
-
-	switch {
-	case m.exponent.IsZero():
-		// base ^ exponent is one, so monome is equal to its coeff
-		return strCoeff
-
-	case m.exponent.isOne():
-		// base ^ exponent is base
-		if m.coeff == 1 {
-			// 1 times base is useless, just return the base
-			return strBase
-		}
-		// result is coeff times base
-		return strCoeff + times + strBase
-
-	default:
-		// general case for the base ^ exponent part
-		result := strBase + " ^ " + leftGroup + m.exponent.string(times, leftGroup, rightGroup) + rightGroup
-		if m.coeff == 1 {
-			// 1 times ... is useless
-			return result
-		}
-		// most general case
-		return strCoeff + times + result
-	}
-}
-
-func (m monome) String() string {
-	return m.string("*", "(", ")")
+	return m.coeff.Cmp(other.coeff)
 }
 
-// eval returns the numeric value of a monome as a *big.Int.
-func (m monome) eval() *big.Int {
-	c := big.NewInt(int64(m.coeff))
-	b := big.NewInt(int64(m.base))
-
+// eval returns the numeric value of a monome in base b as a *big.Int.
+func (m monome) eval(b *big.Int) *big.Int {
 	result := big.NewInt(0)
-	result.Exp(b, m.exponent.Eval(), nil)
-	result.Mul(c, result)
+	result.Exp(b, m.exponent.eval(b), nil)
+	result.Mul(m.coeff, result)
 	return result
 }
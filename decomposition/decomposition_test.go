@@ -1,8 +1,11 @@
 package decomposition
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"math/rand"
 	"testing"
 )
 
@@ -58,6 +61,7 @@ func ExampleDecomposition_LaTeX() {
 
 type goldenMonome struct {
 	m             monome
+	base          int
 	isZero, isOne bool
 	value         *big.Int
 }
@@ -65,30 +69,30 @@ type goldenMonome struct {
 var goldenMonomes = []goldenMonome{
 	{
 		m: monome{
-			coeff:    0,
-			base:     2,
-			exponent: Decomposition{},
+			coeff:    big.NewInt(0),
+			exponent: nil,
 		},
+		base:   2,
 		isZero: true,
 		isOne:  false,
 		value:  big.NewInt(0),
 	},
 	{
 		m: monome{
-			coeff:    1,
-			base:     2,
-			exponent: Decomposition{},
+			coeff:    big.NewInt(1),
+			exponent: nil,
 		},
+		base:   2,
 		isZero: false,
 		isOne:  true,
 		value:  big.NewInt(1),
 	},
 	{
 		m: monome{
-			coeff:    2,
-			base:     3,
-			exponent: Decomposition{},
+			coeff:    big.NewInt(2),
+			exponent: nil,
 		},
+		base:   3,
 		isZero: false,
 		isOne:  false,
 		value:  big.NewInt(2),
@@ -98,21 +102,596 @@ var goldenMonomes = []goldenMonome{
 func TestMonomeIsZero(t *testing.T) {
 	for _, g := range goldenMonomes {
 		if g.m.isZero() != g.isZero {
-			t.Errorf("wrong 'isZero' for %q", g.m)
+			t.Errorf("wrong 'isZero' for %q", newDecomposition(big.NewInt(int64(g.base)), terms{g.m}))
 		}
 	}
 }
 func TestMonomeIsOne(t *testing.T) {
 	for _, g := range goldenMonomes {
 		if g.m.isOne() != g.isOne {
-			t.Errorf("wrong 'isOne' for %q", g.m)
+			t.Errorf("wrong 'isOne' for %q", newDecomposition(big.NewInt(int64(g.base)), terms{g.m}))
 		}
 	}
 }
 func TestMonomeEval(t *testing.T) {
 	for _, g := range goldenMonomes {
-		if g.m.eval().Cmp(g.value) != 0 {
-			t.Errorf("wrong value %v for %q", g.m.eval(), g.m)
+		if v := g.m.eval(big.NewInt(int64(g.base))); v.Cmp(g.value) != 0 {
+			t.Errorf("wrong value %v for %q", v, newDecomposition(big.NewInt(int64(g.base)), terms{g.m}))
+		}
+	}
+}
+
+func TestStringNestedCoefficient(t *testing.T) {
+	// 729 = 3 ^ 6 = 3 ^ (2 * 3)
+	d, _ := New(3, 729)
+	if s := d.String(); s != "3 ^ (2 * 3)" {
+		t.Errorf("wrong string %q", s)
+	}
+}
+
+func TestIncrementBaseShares(t *testing.T) {
+	// IncrementBase must not rebase (nor copy) any exponent:
+	// monomes are shared with the original decomposition.
+	d, _ := New(2, 1000)
+	incremented := d.IncrementBase()
+	if incremented.Base().Int64() != 3 {
+		t.Errorf("wrong base %v", incremented.Base())
+	}
+	if &incremented.monomes[0] != &d.monomes[0] {
+		t.Errorf("monomes have been copied")
+	}
+}
+
+func TestIncrementBaseBeyondInt(t *testing.T) {
+	// 2 * b with b = max int64, then increment the base twice and decrement
+	b := big.NewInt(math.MaxInt64)
+	one := NewBuilderBig(b).Add(1, Decomposition{}).MustBuild()
+	d := NewBuilderBig(b).Add(2, one).MustBuild()
+	d = d.IncrementBase().IncrementBase().Decrement()
+
+	// 2 * (b + 2) ^ 1 - 1 = (b + 2) + (b + 1)
+	b.Add(b, big.NewInt(2))
+	expected := new(big.Int).Add(b, b)
+	expected.Sub(expected, big.NewInt(1))
+	if d.Base().Cmp(b) != 0 || d.Eval().Cmp(expected) != 0 {
+		t.Errorf("wrong decomposition %q in base %v", d, d.Base())
+	}
+}
+
+func TestCleanNoAlloc(t *testing.T) {
+	d, _ := New(3, 123456)
+	allocs := testing.AllocsPerRun(100, func() { d.monomes.clean() })
+	if allocs != 0 {
+		t.Errorf("cleaning a clean decomposition allocates %v times", allocs)
+	}
+}
+
+func TestClean(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		// raw decomposition contains zero-monomes
+		raw := rawDecompose(2, n, 0)
+		cleaned := raw.clean()
+		if !cleaned.isClean() {
+			t.Errorf("%v is not clean", newDecomposition(big.NewInt(2), cleaned))
+		}
+		if v := cleaned.eval(big.NewInt(2)); v.Int64() != int64(n) {
+			t.Errorf("cleaned decomposition of %v has value %v", n, v)
+		}
+	}
+}
+
+func BenchmarkClean(b *testing.B) {
+	d, _ := New(3, 123456)
+	raw := rawDecompose(3, 123456, 0)
+
+	b.Run("clean", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d.monomes.clean()
+		}
+	})
+	b.Run("raw", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			raw.clean()
+		}
+	})
+}
+
+func TestNewBig(t *testing.T) {
+	// same as New for small numbers
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 100; n++ {
+			d, _ := New(b, n)
+			dBig, err := NewBig(b, big.NewInt(int64(n)))
+			if err != nil || dBig.Base().Int64() != int64(b) || dBig.String() != d.String() {
+				t.Errorf("base-%v decomposition of %v: expected %q, got %q (%v)", b, n, d, dBig, err)
+			}
+		}
+	}
+
+	// numbers beyond int
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	d, err := NewBig(7, n)
+	if err != nil || d.Eval().Cmp(n) != 0 || !valid(d.monomes, 7) {
+		t.Errorf("base-7 decomposition of %v: got %q (%v)", n, d, err)
+	}
+
+	// invalid arguments
+	if _, err := NewBig(2, big.NewInt(-1)); err == nil {
+		t.Error("negative number decomposed")
+	}
+	if _, err := NewBig(1, big.NewInt(1)); err == nil {
+		t.Error("base 1 accepted")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	for _, g := range []struct {
+		b1, n1, b2, n2 int
+		equal          bool
+	}{
+		{2, 0, 2, 0, true},
+		{2, 0, 3, 0, false}, // different bases
+		{3, 2, 3, 2, true},
+		{3, 2, 4, 2, false},
+		{2, 10, 2, 10, true},
+		{2, 10, 2, 11, false},
+		{3, 100, 3, 100, true},
+		{3, 100, 3, 101, false},
+	} {
+		d1, _ := New(g.b1, g.n1)
+		d2, _ := New(g.b2, g.n2)
+		if equal := d1.Equal(d2); equal != g.equal {
+			t.Errorf("%q in base %v equal to %q in base %v: expected %v, got %v", d1, g.b1, d2, g.b2, g.equal, equal)
+		}
+	}
+
+	// default value
+	zero, _ := New(2, 0)
+	if !(Decomposition{}).Equal(Decomposition{}) || zero.Equal(Decomposition{}) || (Decomposition{}).Equal(zero) {
+		t.Error("wrong equality of the default value")
+	}
+}
+
+func TestSameShape(t *testing.T) {
+	for _, g := range []struct {
+		b1, n1, b2, n2 int
+		same           bool
+	}{
+		{2, 0, 3, 0, true},
+		{2, 1, 3, 0, true},            // constants only
+		{3, 2 * 9, 4, 2*16 + 3, true}, // 2 * b ^ 2 (+ 3)
+		{3, 2*9 + 1, 3, 9 + 1, false},
+		{3, 3, 3, 1, false}, // b vs constant
+		{2, 4, 2, 5, true},
+		{2, 4, 2, 6, false},
+	} {
+		d1, _ := New(g.b1, g.n1)
+		d2, _ := New(g.b2, g.n2)
+		if same := d1.SameShape(d2); same != g.same {
+			t.Errorf("%q and %q have the same shape: expected %v, got %v", d1, d2, g.same, same)
+		}
+	}
+}
+
+func TestCmp(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 200; i++ {
+		d1, d2 := Rand(3, 2, rng), Rand(3, 2, rng)
+		if d1.Cmp(d2) != d1.Eval().Cmp(d2.Eval()) {
+			t.Errorf("wrong comparison between %q and %q", d1, d2)
+		}
+	}
+}
+
+func TestCmpZero(t *testing.T) {
+	zero, _ := New(2, 0)
+	d, _ := New(3, 5)
+	if zero.Cmp(d) != -1 || d.Cmp(zero) != 1 || (Decomposition{}).Cmp(zero) != 0 {
+		t.Error("wrong comparison with zero")
+	}
+}
+
+func TestCmpBaseMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("comparison of decompositions in different bases did not panic")
+		}
+	}()
+	d2, _ := New(2, 5)
+	d3, _ := New(3, 5)
+	d2.Cmp(d3)
+}
+
+func TestLaTeXTimes(t *testing.T) {
+	d, _ := New(3, 2*27)
+	if s := d.LaTeX(); s != `2 \times 3 ^ {3}` {
+		t.Errorf("wrong LaTeX %q", s)
+	}
+}
+
+func ExampleDecomposition_Typst() {
+	d, _ := New(3, 2*81+2)
+	fmt.Println(d.Typst())
+
+	// Output:
+	// 2 times 3^(3 + 1) + 2
+}
+
+func TestBreakableLaTeX(t *testing.T) {
+	// only top-level plus signs allow a break
+	d, _ := New(2, 16+2+1)
+	if s := d.BreakableLaTeX(); s != `2 ^ {2 ^ {2}} + \allowbreak 2 + \allowbreak 1` {
+		t.Errorf("wrong LaTeX %q", s)
+	}
+	d, _ = New(2, 1<<6)
+	if s := d.BreakableLaTeX(); s != d.LaTeX() {
+		t.Errorf("wrong LaTeX %q", s)
+	}
+}
+
+func ExampleDecomposition_Format() {
+	d, _ := New(3, 2*27+1)
+	fmt.Printf("%v\n", d)
+	fmt.Printf("%+v\n", d)
+	fmt.Printf("%.0v\n", d)
+
+	// Output:
+	// 2 * 3 ^ (3) + 1
+	// 2 \times 3 ^ {3} + 1
+	// 2 * 3 ^ (...) + 1
+}
+
+func TestFormat(t *testing.T) {
+	d, _ := New(2, 16+1) // 2 ^ (2 ^ (2)) + 1
+	for _, g := range []struct {
+		format, expected string
+	}{
+		{"%v", "2 ^ (2 ^ (2)) + 1"},
+		{"%s", "2 ^ (2 ^ (2)) + 1"},
+		{"%q", `"2 ^ (2 ^ (2)) + 1"`},
+		{"%+v", "2 ^ {2 ^ {2}} + 1"},
+		{"%+q", `"2 ^ {2 ^ {2}} + 1"`},
+		{"%.0v", "2 ^ (...) + 1"},
+		{"%.1v", "2 ^ (2 ^ (...)) + 1"},
+		{"%.2v", "2 ^ (2 ^ (2)) + 1"},
+		{"%+.0v", `2 ^ {\dots} + 1`},
+		{"%20v", "   2 ^ (2 ^ (2)) + 1"},
+		{"%-20v|", "2 ^ (2 ^ (2)) + 1   |"},
+		{"%d", "%!d(decomposition.Decomposition=2 ^ (2 ^ (2)) + 1)"},
+		{"%#v", d.GoString()},
+	} {
+		if s := fmt.Sprintf(g.format, d); s != g.expected {
+			t.Errorf("%v: expected %v, got %v", g.format, g.expected, s)
+		}
+	}
+}
+
+func ExampleDecomposition_FormatWith() {
+	d, _ := New(3, 2*81+5)
+	fmt.Println(d.FormatWith(FormatOptions{Superscript: true}))
+	fmt.Println(d.FormatWith(FormatOptions{Times: "·", LeftGroup: "[", RightGroup: "]", Compact: true}))
+
+	// Output:
+	// 2 * 3³⁺¹ + 3 + 2
+	// 2·3^[3+1]+3+2
+}
+
+func TestFormatWith(t *testing.T) {
+	rng := rand.New(rand.NewSource(8))
+	for i := 0; i < 100; i++ {
+		d := Rand(2+i%5, 3, rng)
+		if s := d.FormatWith(FormatOptions{}); s != d.String() {
+			t.Errorf("default options: expected %q, got %q", d.String(), s)
+		}
+		if s := d.FormatWith(FormatOptions{Times: `\times`, LeftGroup: "{", RightGroup: "}"}); s != d.LaTeX() {
+			t.Errorf("LaTeX options: expected %q, got %q", d.LaTeX(), s)
+		}
+	}
+
+	// only simple exponents are superscripts
+	d, _ := New(2, 1<<5+1<<2)
+	if s := d.FormatWith(FormatOptions{Superscript: true}); s != "2 ^ (2² + 1) + 2²" {
+		t.Errorf("wrong superscripts %q", s)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	d := Rand(10, 4, rand.New(rand.NewSource(1)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = d.String()
+	}
+}
+
+func TestWithBase(t *testing.T) {
+	d, _ := New(3, 2*27+1) // 2 * 3 ^ (3) + 1
+
+	// bumping the base symbolically
+	rebased, err := d.WithBase(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := rebased.String(); s != "2 * 10 ^ (10) + 1" {
+		t.Errorf("wrong decomposition %q", s)
+	}
+	if bumped, _ := d.WithBase(4); !bumped.Equal(d.IncrementBase()) {
+		t.Errorf("WithBase(4) differs from IncrementBase: %v", bumped)
+	}
+
+	// invalid bases
+	for _, b := range []int{-1, 0, 1, 2} {
+		if _, err := d.WithBase(b); err == nil {
+			t.Errorf("base %v: expecting an error", b)
+		}
+	}
+
+	// zero has no coefficient
+	var zero Decomposition
+	if z, err := zero.WithBase(2); err != nil || !z.IsZero() || z.Base().Int64() != 2 {
+		t.Errorf("zero in base 2: got %v (base %v), %v", z, z.Base(), err)
+	}
+}
+
+func TestWithBaseBig(t *testing.T) {
+	d, _ := New(3, 2*27+1) // 2 * 3 ^ (3) + 1
+
+	// a base which does not fit in an int
+	b, _ := new(big.Int).SetString("100000000000000000000", 10)
+	rebased, err := d.WithBaseBig(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := rebased.String(); s != "2 * 100000000000000000000 ^ (100000000000000000000) + 1" {
+		t.Errorf("wrong decomposition %q", s)
+	}
+	b.SetInt64(4)
+	if rebased.Base().Cmp(b) == 0 {
+		t.Errorf("base is retained")
+	}
+	if _, err := d.WithBaseBig(big.NewInt(2)); err == nil {
+		t.Errorf("base 2: expecting an error")
+	}
+}
+
+func TestRebase(t *testing.T) {
+	for _, g := range []struct {
+		b, n, newBase int
+	}{
+		{2, 0, 3},
+		{2, 10, 3},
+		{3, 2*27 + 1, 2},
+		{10, 12345, 7},
+	} {
+		d, _ := New(g.b, g.n)
+		rebased, err := d.Rebase(g.newBase)
+		if err != nil {
+			t.Errorf("base-%v decomposition of %v: unexpected error %v", g.b, g.n, err)
+			continue
+		}
+		expected, _ := New(g.newBase, g.n)
+		if !rebased.Equal(expected) {
+			t.Errorf("base-%v decomposition of %v: expected %v, got %v", g.b, g.n, expected, rebased)
+		}
+	}
+
+	// symbolic and numeric base bumping agree
+	d, _ := New(2, 4) // 2 ^ (2)
+	rebased, _ := d.IncrementBase().Rebase(3)
+	if s := rebased.String(); s != "3 ^ (3)" {
+		t.Errorf("wrong decomposition %q", s)
+	}
+
+	// invalid base
+	if _, err := d.Rebase(1); err == nil {
+		t.Errorf("base 1: expecting an error")
+	}
+}
+
+func TestIncrementBaseBy(t *testing.T) {
+	d, _ := New(2, 10)
+	for k := 0; k < 5; k++ {
+		expected := d
+		for i := 0; i < k; i++ {
+			expected = expected.IncrementBase()
+		}
+		if bumped := d.IncrementBaseBy(k); !bumped.Equal(expected) {
+			t.Errorf("increment by %v: expected %v, got %v", k, expected, bumped)
+		}
+	}
+
+	// the default value has no base
+	var zero Decomposition
+	if bumped := zero.IncrementBaseBy(3); !bumped.Equal(zero) {
+		t.Errorf("zero: got base %v", bumped.Base())
+	}
+
+	// negative increments panic
+	defer func() {
+		if recover() == nil {
+			t.Errorf("negative increment: expecting a panic")
+		}
+	}()
+	d.IncrementBaseBy(-1)
+}
+
+func TestNewPower(t *testing.T) {
+	for b := 2; b < 5; b++ {
+		for e := 0; e < 10; e++ {
+			d, err := NewPower(b, e)
+			if err != nil {
+				t.Errorf("%v ^ %v: unexpected error %v", b, e, err)
+				continue
+			}
+			n := 1
+			for i := 0; i < e; i++ {
+				n *= b
+			}
+			if expected, _ := New(b, n); !d.Equal(expected) {
+				t.Errorf("%v ^ %v: expected %q, got %q", b, e, expected, d)
+			}
+		}
+	}
+
+	// invalid arguments
+	if _, err := NewPower(1, 2); err == nil {
+		t.Errorf("base 1: expecting an error")
+	}
+	if _, err := NewPower(2, -1); err == nil {
+		t.Errorf("negative exponent: expecting an error")
+	}
+}
+
+func ExampleNewTower() {
+	for height := 0; height < 4; height++ {
+		d, _ := NewTower(3, height)
+		fmt.Println(d)
+	}
+
+	// Output:
+	// 1
+	// 3
+	// 3 ^ (3)
+	// 3 ^ (3 ^ (3))
+}
+
+func TestNewTower(t *testing.T) {
+	// small towers can be checked against their values
+	for _, g := range []struct {
+		b, height, n int
+	}{
+		{2, 0, 1},
+		{2, 1, 2},
+		{2, 2, 4},
+		{2, 3, 16},
+		{2, 4, 65536},
+		{3, 2, 27},
+	} {
+		d, _ := NewTower(g.b, g.height)
+		if expected, _ := New(g.b, g.n); !d.Equal(expected) {
+			t.Errorf("base-%v tower of height %v: expected %q, got %q", g.b, g.height, expected, d)
+		}
+	}
+
+	// invalid arguments
+	if _, err := NewTower(1, 2); err == nil {
+		t.Errorf("base 1: expecting an error")
+	}
+	if _, err := NewTower(2, -1); err == nil {
+		t.Errorf("negative height: expecting an error")
+	}
+}
+
+// rawDecompose recursively builds the hereditary base-b decomposition of n
+// like decompose but with zero monomes, e.g. to test clean.
+func rawDecompose(b, n, k int) terms {
+	if n == 0 {
+		return nil
+	}
+	singleton := terms{
+		monome{
+			coeff:    big.NewInt(int64(n % b)),
+			exponent: rawDecompose(b, k, 0),
+		},
+	}
+	return append(singleton, rawDecompose(b, n/b, k+1)...)
+}
+
+func TestDecompose(t *testing.T) {
+	for b := 2; b < 6; b++ {
+		for n := 0; n < 1000; n++ {
+			d := decompose(b, n)
+			if !d.isClean() {
+				t.Errorf("base-%v decomposition of %v is not clean", b, n)
+			}
+			if d.cmp(rawDecompose(b, n, 0).clean()) != 0 {
+				t.Errorf("wrong base-%v decomposition of %v", b, n)
+			}
+		}
+	}
+
+	// largest int
+	d := decompose(2, math.MaxInt)
+	if v := d.eval(big.NewInt(2)); v.Cmp(big.NewInt(math.MaxInt)) != 0 {
+		t.Errorf("wrong decomposition of MaxInt: %v", v)
+	}
+}
+
+func TestDecrementShares(t *testing.T) {
+	d, _ := New(3, 2*81+3+1) // 2 * 3 ^ (3 + 1) + 3 + 1
+	s := d.String()
+	decremented := d.Decrement()
+
+	// the original decomposition is left unchanged
+	if d.String() != s {
+		t.Errorf("decrement modified %v into %v", s, d)
+	}
+
+	// and unchanged monomes are shared:
+	// the constant monome has been removed so monomes are shifted
+	last := len(d.monomes) - 1
+	if &decremented.monomes[last-1].exponent[0] != &d.monomes[last].exponent[0] {
+		t.Errorf("exponent of the most significant monome is not shared")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// decompositions built by the package are valid
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 300; n++ {
+			d, _ := New(b, n)
+			if err := d.Validate(); err != nil {
+				t.Errorf("base-%v decomposition of %v: %v", b, n, err)
+			}
+		}
+	}
+	if err := (Decomposition{}).Validate(); err != nil {
+		t.Errorf("default value: %v", err)
+	}
+
+	// corrupted ones are not
+	one := terms{{coeff: bigOne}}
+	for i, d := range []Decomposition{
+		{monomes: one},
+		{base: big.NewInt(1)},
+		{base: big.NewInt(3), monomes: terms{{coeff: big.NewInt(3)}}},
+		{base: big.NewInt(3), monomes: terms{{coeff: big.NewInt(0)}}},
+		{base: big.NewInt(3), monomes: terms{{coeff: bigOne, exponent: one}, {coeff: bigOne}}},
+		{base: big.NewInt(3), monomes: terms{{coeff: bigOne}, {coeff: bigOne}}},
+		{base: big.NewInt(3), monomes: terms{{coeff: bigOne, exponent: terms{{coeff: big.NewInt(5)}}}}},
+	} {
+		if err := d.Validate(); err == nil {
+			t.Errorf("corrupted decomposition %v: expecting an error", i)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	three, _ := New(3, 3)
+	two, _ := New(2, 2)
+	corrupted := Decomposition{base: big.NewInt(3), monomes: terms{{coeff: big.NewInt(3)}}}
+	for _, g := range []struct {
+		name     string
+		err      func() error
+		expected error
+	}{
+		{"New negative", func() error { _, err := New(2, -1); return err }, ErrNegative},
+		{"New base", func() error { _, err := New(1, 1); return err }, ErrBaseTooSmall},
+		{"NewBig negative", func() error { _, err := NewBig(2, big.NewInt(-1)); return err }, ErrNegative},
+		{"NewBig base", func() error { _, err := NewBig(0, big.NewInt(1)); return err }, ErrBaseTooSmall},
+		{"NewPower negative", func() error { _, err := NewPower(2, -1); return err }, ErrNegative},
+		{"NewTower base", func() error { _, err := NewTower(1, 2); return err }, ErrBaseTooSmall},
+		{"WithBase", func() error { _, err := three.WithBase(1); return err }, ErrBaseTooSmall},
+		{"WithBase coefficient", func() error { _, err := three.MulScalar(2).WithBase(2); return err }, ErrInvalidDecomposition},
+		{"Validate", corrupted.Validate, ErrInvalidDecomposition},
+		{"Mul", func() error { _, err := Mul(two, three); return err }, ErrBaseMismatch},
+		{"Builder coefficient", func() error { _, err := NewBuilder(2).Add(2, Decomposition{}).Build(); return err }, ErrInvalidDecomposition},
+		{"Builder exponent", func() error { _, err := NewBuilder(2).Add(1, three).Build(); return err }, ErrBaseMismatch},
+		{"ParseBase", func() error { _, err := ParseBase(2, "3 ^ (3)"); return err }, ErrBaseMismatch},
+	} {
+		if err := g.err(); !errors.Is(err, g.expected) {
+			t.Errorf("%v: expecting %v, got %v", g.name, g.expected, err)
 		}
 	}
 }
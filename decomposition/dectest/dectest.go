@@ -0,0 +1,46 @@
+// Package dectest implements support for testing code
+// built on top of the decomposition package.
+package dectest
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// CheckStep checks that after results from a Goodstein step applied to before,
+// i.e. that the base has been incremented by one and that one has been removed.
+// It returns an error describing the first broken invariant, if any.
+//
+// The following invariants are checked:
+//   - before is not zero since the sequence stops at zero;
+//   - the base of after is the base of before plus one;
+//   - the value of after is the value of before in the new base minus one;
+//   - the ordinal of after, its base replaced by ω, is lower than the one of before.
+func CheckStep(before, after decomposition.Decomposition) error {
+	// there is no step after zero
+	if before.IsZero() {
+		return fmt.Errorf("step from zero decomposition")
+	}
+
+	// base must be incremented
+	if new(big.Int).Sub(after.Base(), before.Base()).Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("base %v after step from base %v", after.Base(), before.Base())
+	}
+
+	// value must be decremented
+	bumped := before.IncrementBase()
+	expected := new(big.Int).Sub(bumped.Eval(), big.NewInt(1))
+	if value := after.Eval(); value.Cmp(expected) != 0 {
+		return fmt.Errorf("value %v after step, expecting %v", value, expected)
+	}
+
+	// ordinal must decrease
+	// (coefficients must fit in an int, see Decomposition.ToOrdinal)
+	if a, b := after.ToOrdinal(), before.ToOrdinal(); a.Cmp(b) >= 0 {
+		return fmt.Errorf("ordinal %v of %q is not lower than ordinal %v of %q", a, after, b, before)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package dectest
+
+import (
+	"testing"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+func TestCheckStep(t *testing.T) {
+	for n := 1; n < 16; n++ {
+		d, _ := decomposition.New(2, n)
+		for i := 0; i < 6 && !d.IsZero(); i++ {
+			next := d.IncrementBase().Decrement()
+			if err := CheckStep(d, next); err != nil {
+				t.Errorf("valid step from %q to %q: %v", d, next, err)
+			}
+			d = next
+		}
+	}
+}
+
+func TestCheckStepInvalid(t *testing.T) {
+	zero := decomposition.Decomposition{}
+	ten, _ := decomposition.New(2, 10)
+	nine, _ := decomposition.New(2, 9)
+	twentySeven, _ := decomposition.New(3, 27)
+
+	for _, g := range []struct {
+		before, after decomposition.Decomposition
+	}{
+		{zero, zero},                       // no step from zero
+		{ten, nine},                        // base not incremented
+		{ten, ten.IncrementBase()},         // value not decremented
+		{ten, twentySeven.IncrementBase()}, // base incremented twice
+	} {
+		if err := CheckStep(g.before, g.after); err == nil {
+			t.Errorf("invalid step from %q to %q not detected", g.before, g.after)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package decomposition
+
+import (
+	"errors"
+)
+
+// ErrNegative is returned when a number which must not be negative is.
+var ErrNegative = errors.New("negative number")
+
+// ErrBaseTooSmall is returned when a base is lower than 2.
+var ErrBaseTooSmall = errors.New("base must be at least 2")
+
+// ErrBaseMismatch is returned when decompositions in different bases are combined.
+var ErrBaseMismatch = errors.New("base mismatch")
+
+// ErrInvalidDecomposition is returned when a decomposition breaks an invariant,
+// e.g. a coefficient is not lower than the base or exponents are not sorted.
+var ErrInvalidDecomposition = errors.New("invalid decomposition")
@@ -0,0 +1,112 @@
+package decomposition
+
+import (
+	"context"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// EvalContext is like Eval but it stops as soon as the context is done,
+// returning the context error.
+// Cancellation is checked between the multiplications of each exponentiation,
+// so that an evaluation which would not terminate in any reasonable time
+// (e.g. of a deep decomposition) can be aborted cleanly.
+func (d Decomposition) EvalContext(ctx context.Context) (*big.Int, error) {
+	return d.monomes.evalContext(ctx, d.base)
+}
+
+// evalContext evaluates base-b terms unless the context is done.
+func (t terms) evalContext(ctx context.Context, b *big.Int) (*big.Int, error) {
+	result := big.NewInt(0)
+	for _, m := range t {
+		v, err := m.evalContext(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		result.Add(result, v)
+	}
+	return result, nil
+}
+
+// evalContext evaluates a base-b monome unless the context is done.
+func (m monome) evalContext(ctx context.Context, b *big.Int) (*big.Int, error) {
+	e, err := m.exponent.evalContext(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	result, err := expContext(ctx, b, e)
+	if err != nil {
+		return nil, err
+	}
+	return result.Mul(m.coeff, result), nil
+}
+
+// expContext returns b ^ e, computed by squaring from the most significant bit of e,
+// unless the context is done.
+func expContext(ctx context.Context, b, e *big.Int) (*big.Int, error) {
+	result := big.NewInt(1)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result.Mul(result, result)
+		if e.Bit(i) == 1 {
+			result.Mul(result, b)
+		}
+	}
+	return result, nil
+}
+
+// EvalParallel is like Eval but top-level monomes are evaluated concurrently
+// by n workers, GOMAXPROCS if n is not positive, and their values are summed.
+// Wide decompositions, e.g. after deep decrements, are evaluated much faster on several cores.
+// Like Eval, the value is computed once and cached in the decomposition.
+func (d Decomposition) EvalParallel(n int) *big.Int {
+	if d.value == nil {
+		return d.monomes.evalParallel(d.base, n)
+	}
+	d.value.once.Do(func() {
+		d.value.value = d.monomes.evalParallel(d.base, n)
+	})
+	return new(big.Int).Set(d.value.value)
+}
+
+// evalParallel evaluates base-b terms with at most n workers, GOMAXPROCS if n is not positive,
+// one per monome.
+func (t terms) evalParallel(b *big.Int, n int) *big.Int {
+	// a single monome gains nothing from workers
+	if len(t) <= 1 {
+		return t.eval(b)
+	}
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	n = min(n, len(t))
+
+	// evaluate monomes
+	values := make([]*big.Int, len(t))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				values[i] = t[i].eval(b)
+			}
+		}()
+	}
+	for i := range t {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	// and sum them
+	sum := big.NewInt(0)
+	for _, v := range values {
+		sum.Add(sum, v)
+	}
+	return sum
+}
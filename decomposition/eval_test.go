@@ -0,0 +1,105 @@
+package decomposition
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestEvalContext(t *testing.T) {
+	// same values as Eval
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 200; n++ {
+			d, _ := New(b, n)
+			v, err := d.EvalContext(context.Background())
+			if err != nil {
+				t.Errorf("base-%v decomposition of %v: unexpected error %v", b, n, err)
+				continue
+			}
+			if v.Int64() != int64(n) {
+				t.Errorf("base-%v decomposition of %v: got %v", b, n, v)
+			}
+		}
+	}
+
+	// 2 ^ 2 ^ 2 ^ 2 ^ 2 ^ 2 does not fit in memory
+	d, _ := NewTower(2, 6)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := d.EvalContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expecting deadline exceeded, got %v", err)
+	}
+}
+
+func TestEvalCache(t *testing.T) {
+	d, _ := New(3, 100)
+	copied := d
+
+	// the returned value can be modified without altering the cached one
+	v := d.Eval()
+	v.SetInt64(0)
+	if v := copied.Eval(); v.Int64() != 100 {
+		t.Errorf("cached value altered: got %v", v)
+	}
+
+	// decompositions derived from d have their own value
+	if v := d.IncrementBase().Eval(); v.Int64() != 1024+2*16+1 {
+		t.Errorf("wrong value of %v: %v", d.IncrementBase(), v)
+	}
+}
+
+func TestEvalParallel(t *testing.T) {
+	for _, workers := range []int{0, 1, 3} {
+		for b := 2; b < 5; b++ {
+			for n := 0; n < 200; n++ {
+				d, _ := New(b, n)
+				if v := d.EvalParallel(workers); v.Int64() != int64(n) {
+					t.Errorf("base-%v decomposition of %v with %v workers: got %v", b, n, workers, v)
+				}
+			}
+		}
+	}
+
+	// wide decomposition: 29 * 30 ^ (29) + 29 * 30 ^ (28) + ... + 29
+	d, _ := NewPower(30, 30)
+	d = d.Decrement()
+	if v := d.EvalParallel(4); v.Cmp(d.Eval()) != 0 {
+		t.Errorf("%v: expected %v, got %v", d, d.Eval(), v)
+	}
+
+	// more workers than monomes, a single monome and the default value
+	if v := d.EvalParallel(100); v.Cmp(d.Eval()) != 0 {
+		t.Errorf("%v with 100 workers: expected %v, got %v", d, d.Eval(), v)
+	}
+	single, _ := NewPower(30, 30)
+	if v := single.EvalParallel(4); v.Cmp(single.Eval()) != 0 {
+		t.Errorf("%v: expected %v, got %v", single, single.Eval(), v)
+	}
+	if v := (Decomposition{}).EvalParallel(4); v.Sign() != 0 {
+		t.Errorf("default value: expected 0, got %v", v)
+	}
+}
+
+func TestEvalParallelCached(t *testing.T) {
+	d, _ := NewPower(30, 30)
+	d = d.Decrement()
+	expected := d.Decrement().Eval()
+	expected.Add(expected, big.NewInt(1))
+
+	// the parallel value is cached and returned as a copy
+	v := d.EvalParallel(4)
+	v.SetInt64(0)
+	if v := d.Eval(); v.Cmp(expected) != 0 {
+		t.Errorf("%v: expected %v, got %v", d, expected, v)
+	}
+
+	// and a cached value is returned without evaluation
+	d = d.Decrement()
+	expected.Sub(expected, big.NewInt(1))
+	d.Eval()
+	if v := d.EvalParallel(4); v.Cmp(expected) != 0 {
+		t.Errorf("%v: expected %v, got %v", d, expected, v)
+	}
+}
@@ -0,0 +1,238 @@
+package decomposition
+
+import (
+	"strings"
+)
+
+// wolframFormatter writes decompositions as Wolfram Language expressions in infix form.
+var wolframFormatter = formatter{
+	times:      "*",
+	power:      "^",
+	leftGroup:  "(",
+	rightGroup: ")",
+	plus:       "+",
+	nestedPlus: "+",
+	maxDepth:   -1,
+}
+
+// Wolfram returns the decomposition as a Wolfram Language expression
+// in infix form, e.g. "2^(2+1)+2", which can be evaluated in Mathematica
+// to check the value of the decomposition independently.
+func (d Decomposition) Wolfram() string {
+	return wolframFormatter.format(d.base, d.monomes)
+}
+
+// WolframFullForm is like Wolfram but the expression is written
+// with explicit Plus, Times and Power heads,
+// e.g. "Plus[Power[2, Plus[2, 1]], 2]".
+func (d Decomposition) WolframFullForm() string {
+	var sb strings.Builder
+	writeFullForm(&sb, d.base.String(), d.monomes)
+	return sb.String()
+}
+
+// writeFullForm writes base-b terms with explicit Wolfram Language heads,
+// most significant monomes first.
+func writeFullForm(sb *strings.Builder, b string, t terms) {
+	switch len(t) {
+	case 0:
+		sb.WriteString("0")
+	case 1:
+		writeMonomeFullForm(sb, b, t[0])
+	default:
+		sb.WriteString("Plus[")
+		for i := len(t) - 1; i >= 0; i-- {
+			writeMonomeFullForm(sb, b, t[i])
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+		}
+		sb.WriteString("]")
+	}
+}
+
+// writeMonomeFullForm writes a base-b monome with explicit Wolfram Language heads.
+func writeMonomeFullForm(sb *strings.Builder, b string, m monome) {
+	// constant
+	if m.exponent.isZero() {
+		writeInt(sb, m.coeff)
+		return
+	}
+
+	// coefficient (or not)
+	times := m.coeff.Cmp(bigOne) != 0
+	if times {
+		sb.WriteString("Times[")
+		writeInt(sb, m.coeff)
+		sb.WriteString(", ")
+	}
+
+	// base ^ exponent
+	if m.exponent.isOne() {
+		sb.WriteString(b)
+	} else {
+		sb.WriteString("Power[")
+		sb.WriteString(b)
+		sb.WriteString(", ")
+		writeFullForm(sb, b, m.exponent)
+		sb.WriteString("]")
+	}
+
+	if times {
+		sb.WriteString("]")
+	}
+}
+
+// pythonFormatter writes decompositions as Python expressions.
+var pythonFormatter = formatter{
+	times:      "*",
+	power:      "**",
+	leftGroup:  "(",
+	rightGroup: ")",
+	plus:       "+",
+	nestedPlus: "+",
+	maxDepth:   -1,
+}
+
+// Python returns the decomposition as a Python expression, e.g. "2**(2+1)+2",
+// which evaluates to the value of the decomposition.
+func (d Decomposition) Python() string {
+	return pythonFormatter.format(d.base, d.monomes)
+}
+
+// SymPy is like Python but numbers are SymPy Integer literals,
+// e.g. "Integer(2)**(Integer(2)+Integer(1))+Integer(2)",
+// so that the expression can be given to sympy.sympify.
+func (d Decomposition) SymPy() string {
+	f := pythonFormatter
+	f.numberPrefix, f.numberSuffix = "Integer(", ")"
+	return f.format(d.base, d.monomes)
+}
+
+// htmlFormatter writes decompositions as HTML fragments.
+var htmlFormatter = formatter{
+	times:      " &times; ",
+	leftGroup:  "<sup>",
+	rightGroup: "</sup>",
+	plus:       " + ",
+	nestedPlus: " + ",
+	ellipsis:   "&hellip;",
+	maxDepth:   -1,
+}
+
+// HTML returns the decomposition as an HTML fragment with nested superscripts,
+// e.g. "2 &times; 3<sup>3 + 1</sup> + 2",
+// which can be embedded in a web page without any math rendering.
+func (d Decomposition) HTML() string {
+	return htmlFormatter.format(d.base, d.monomes)
+}
+
+// MathML returns the decomposition as a presentation MathML element,
+// e.g. "<math><msup><mn>2</mn><mrow><mn>2</mn></mrow></msup><mo>+</mo><mn>1</mn></math>",
+// which can be embedded in a web page rendering MathML.
+func (d Decomposition) MathML() string {
+	var sb strings.Builder
+	sb.WriteString("<math>")
+	writeMathML(&sb, d.base.String(), d.monomes)
+	sb.WriteString("</math>")
+	return sb.String()
+}
+
+// writeMathML writes base-b terms as MathML elements,
+// most significant monomes first.
+func writeMathML(sb *strings.Builder, b string, t terms) {
+	if len(t) == 0 {
+		sb.WriteString("<mn>0</mn>")
+		return
+	}
+	for i := len(t) - 1; i >= 0; i-- {
+		writeMonomeMathML(sb, b, t[i])
+		if i > 0 {
+			sb.WriteString("<mo>+</mo>")
+		}
+	}
+}
+
+// writeMonomeMathML writes a base-b monome as MathML elements.
+func writeMonomeMathML(sb *strings.Builder, b string, m monome) {
+	// constant
+	if m.exponent.isZero() {
+		sb.WriteString("<mn>")
+		writeInt(sb, m.coeff)
+		sb.WriteString("</mn>")
+		return
+	}
+
+	// coefficient (or not)
+	if m.coeff.Cmp(bigOne) != 0 {
+		sb.WriteString("<mn>")
+		writeInt(sb, m.coeff)
+		sb.WriteString("</mn><mo>&#xD7;</mo>")
+	}
+
+	// base ^ exponent
+	if m.exponent.isOne() {
+		sb.WriteString("<mn>" + b + "</mn>")
+		return
+	}
+	sb.WriteString("<msup><mn>" + b + "</mn><mrow>")
+	writeMathML(sb, b, m.exponent)
+	sb.WriteString("</mrow></msup>")
+}
+
+// SExpr returns the decomposition as a prefix S-expression,
+// e.g. "(+ (* 2 (^ 3 (+ 3 1))) 2)", which can be evaluated by Lisp-like languages
+// provided that ^ is defined as exponentiation.
+func (d Decomposition) SExpr() string {
+	var sb strings.Builder
+	writeSExpr(&sb, d.base.String(), d.monomes)
+	return sb.String()
+}
+
+// writeSExpr writes base-b terms as an S-expression,
+// most significant monomes first.
+func writeSExpr(sb *strings.Builder, b string, t terms) {
+	switch len(t) {
+	case 0:
+		sb.WriteString("0")
+	case 1:
+		writeMonomeSExpr(sb, b, t[0])
+	default:
+		sb.WriteString("(+")
+		for i := len(t) - 1; i >= 0; i-- {
+			sb.WriteString(" ")
+			writeMonomeSExpr(sb, b, t[i])
+		}
+		sb.WriteString(")")
+	}
+}
+
+// writeMonomeSExpr writes a base-b monome as an S-expression.
+func writeMonomeSExpr(sb *strings.Builder, b string, m monome) {
+	// constant
+	if m.exponent.isZero() {
+		writeInt(sb, m.coeff)
+		return
+	}
+
+	// coefficient (or not)
+	times := m.coeff.Cmp(bigOne) != 0
+	if times {
+		sb.WriteString("(* ")
+		writeInt(sb, m.coeff)
+		sb.WriteString(" ")
+	}
+
+	// base ^ exponent
+	if m.exponent.isOne() {
+		sb.WriteString(b)
+	} else {
+		sb.WriteString("(^ " + b + " ")
+		writeSExpr(sb, b, m.exponent)
+		sb.WriteString(")")
+	}
+
+	if times {
+		sb.WriteString(")")
+	}
+}
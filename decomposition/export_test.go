@@ -0,0 +1,77 @@
+package decomposition
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleDecomposition_Wolfram() {
+	d, _ := New(2, 10)
+	fmt.Println(d.Wolfram())
+	fmt.Println(d.WolframFullForm())
+
+	// Output:
+	// 2^(2+1)+2
+	// Plus[Power[2, Plus[2, 1]], 2]
+}
+
+func TestWolframFullForm(t *testing.T) {
+	for _, g := range []struct {
+		b, n     int
+		expected string
+	}{
+		{2, 0, "0"},
+		{3, 2, "2"},
+		{3, 6, "Times[2, 3]"},
+		{3, 2*27 + 1, "Plus[Times[2, Power[3, 3]], 1]"},
+	} {
+		d, _ := New(g.b, g.n)
+		if s := d.WolframFullForm(); s != g.expected {
+			t.Errorf("base-%v decomposition of %v: expected %v, got %v", g.b, g.n, g.expected, s)
+		}
+	}
+}
+
+func ExampleDecomposition_Python() {
+	d, _ := New(3, 2*27+1)
+	fmt.Println(d.Python())
+	fmt.Println(d.SymPy())
+
+	// Output:
+	// 2*3**(3)+1
+	// Integer(2)*Integer(3)**(Integer(3))+Integer(1)
+}
+
+func ExampleDecomposition_HTML() {
+	d, _ := New(2, 16+2)
+	fmt.Println(d.HTML())
+
+	// Output:
+	// 2<sup>2<sup>2</sup></sup> + 2
+}
+
+func ExampleDecomposition_MathML() {
+	d, _ := New(2, 5)
+	fmt.Println(d.MathML())
+
+	// Output:
+	// <math><msup><mn>2</mn><mrow><mn>2</mn></mrow></msup><mo>+</mo><mn>1</mn></math>
+}
+
+func TestSExpr(t *testing.T) {
+	for _, g := range []struct {
+		b, n     int
+		expected string
+	}{
+		{2, 0, "0"},
+		{3, 2, "2"},
+		{3, 6, "(* 2 3)"},
+		{3, 2*27 + 1, "(+ (* 2 (^ 3 3)) 1)"},
+		{2, 10, "(+ (^ 2 (+ 2 1)) 2)"},
+	} {
+		d, _ := New(g.b, g.n)
+		if s := d.SExpr(); s != g.expected {
+			t.Errorf("base-%v decomposition of %v: expected %v, got %v", g.b, g.n, g.expected, s)
+		}
+	}
+}
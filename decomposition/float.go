@@ -0,0 +1,226 @@
+package decomposition
+
+import (
+	"math"
+	"math/big"
+)
+
+// EvalFloat returns an approximation of the value of the decomposition
+// with prec bits of mantissa, 53 (the precision of a float64) if prec is 0.
+// It returns +Inf if the value overflows the exponent of a big.Float,
+// i.e. if it is 2 ^ big.MaxExp or more,
+// so that magnitudes can be displayed for values far too large to be computed exactly.
+func (d Decomposition) EvalFloat(prec uint) *big.Float {
+	if prec == 0 {
+		prec = 53
+	}
+	return d.monomes.evalFloat(d.base, prec)
+}
+
+// evalFloat returns an approximation of the value of base-b terms with prec bits of mantissa.
+func (t terms) evalFloat(b *big.Int, prec uint) *big.Float {
+	sum := new(big.Float).SetPrec(prec)
+	for _, m := range t {
+		sum.Add(sum, m.evalFloat(b, prec))
+	}
+	return sum
+}
+
+// evalFloat returns an approximation of the value of a base-b monome with prec bits of mantissa.
+func (m monome) evalFloat(b *big.Int, prec uint) *big.Float {
+	result := new(big.Float).SetPrec(prec).SetInt(m.coeff)
+
+	// exponents are computed with at least 64 bits of mantissa
+	// so that they are exact whenever they fit in an int64
+	e := m.exponent.evalFloat(b, max(prec, 64))
+	if e.Sign() == 0 {
+		return result
+	}
+
+	// since b is at least 2, b ^ e overflows if e does not fit in an int32
+	if e.IsInf() || e.Cmp(big.NewFloat(math.MaxInt32)) > 0 {
+		return result.SetInf(false)
+	}
+	n, _ := e.Int64()
+
+	// exponentiation by squaring, with guard bits against rounding errors
+	x := new(big.Float).SetPrec(prec + 64).SetInt(b)
+	pow := new(big.Float).SetPrec(prec + 64).SetInt64(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			pow.Mul(pow, x)
+		}
+		if n > 1 {
+			x.Mul(x, x)
+		}
+	}
+	return result.Mul(result, pow)
+}
+
+// Log2 returns an approximation of the binary logarithm of the value of the decomposition,
+// computed from its structure rather than from its value,
+// so that it is available for values with more digits than memory allows.
+// It returns -Inf for a zero decomposition and +Inf if the logarithm itself
+// overflows the exponent of a big.Float.
+func (d Decomposition) Log2() *big.Float {
+	return d.monomes.log2(d.base)
+}
+
+// log2 returns an approximation of the binary logarithm of the value of base-b terms.
+func (t terms) log2(b *big.Int) *big.Float {
+	if t.isZero() {
+		return new(big.Float).SetInf(true)
+	}
+
+	// binary logarithm of each monome: log2(coeff) + exponent * log2(b)
+	logB := big.NewFloat(log2Int(b))
+	logs := make([]*big.Float, len(t))
+	for i, m := range t {
+		l := m.exponent.evalFloat(b, 64)
+		l.Mul(l, logB)
+		logs[i] = l.Add(l, big.NewFloat(log2Int(m.coeff)))
+	}
+
+	// the most significant monome is greater than the sum of all others:
+	// the logarithm of the sum is the one of this monome plus a small correction
+	log := logs[len(logs)-1]
+	if log.IsInf() {
+		return log
+	}
+	correction := 1.0
+	for _, l := range logs[:len(logs)-1] {
+		diff, _ := new(big.Float).Sub(l, log).Float64()
+		correction += math.Exp2(diff)
+	}
+	return log.Add(log, big.NewFloat(math.Log2(correction)))
+}
+
+// log2Int returns the binary logarithm of positive n, even if it does not fit in a float64.
+func log2Int(n *big.Int) float64 {
+	mant := new(big.Float).SetInt(n)
+	exp := mant.MantExp(mant)
+	f, _ := mant.Float64()
+	return math.Log2(f) + float64(exp)
+}
+
+// exactDigitsBits is the number of bits below which
+// the number of digits is computed from the exact value.
+const exactDigitsBits = 1 << 14
+
+// maxDigitsExponentBits is the maximum number of bits of the most significant exponent
+// for which the number of digits is computed, which takes a time quadratic in this number.
+const maxDigitsExponentBits = 1 << 16
+
+// compareDigitsBits is the number of bits below which values too close to a power of ten
+// to be told apart from it by their logarithm are compared to it exactly.
+const compareDigitsBits = 1 << 20
+
+// NumDecimalDigits returns the number of decimal digits of the value of the decomposition.
+// It is computed from the exact value for values of less than a few thousand digits
+// and from the decimal logarithm of the value otherwise,
+// computed in fixed point with a precision scaled to the most significant exponent.
+// The count is exact, except for values of more than a million bits within a relative 2^-80
+// of a power of ten: they are counted like this power of ten,
+// i.e. one digit too many if they are lower.
+// It returns nil if the most significant exponent has more than 65536 bits,
+// the number of digits being too large to be computed.
+func (d Decomposition) NumDecimalDigits() *big.Int {
+	if d.IsZero() {
+		return big.NewInt(1)
+	}
+
+	log2 := d.Log2()
+	if log2.IsInf() {
+		return nil
+	}
+	if log2.Cmp(big.NewFloat(exactDigitsBits)) < 0 {
+		return big.NewInt(int64(len(d.Eval().String())))
+	}
+
+	// the value is b ^ e * (c + f) where c * b ^ e is the most significant monome and f is in [0, 1),
+	// hence log10(value) = (e * ln(b) + ln(c + f)) / ln(10)
+	top := d.monomes[len(d.monomes)-1]
+	if top.exponent.log2(d.base).Cmp(big.NewFloat(maxDigitsExponentBits)) >= 0 {
+		return nil
+	}
+	e := top.exponent.eval(d.base)
+	prec := uint(e.BitLen()) + 128
+	num, den := d.monomes.leadRatio(d.base, e)
+	log := new(big.Int).Mul(e, lnFixed(d.base, bigOne, prec))
+	log.Add(log, lnFixed(num, den, prec))
+	ln10 := lnFixed(big.NewInt(10), bigOne, prec)
+
+	// floor(log10(value)) + 1, unless log10(value) is too close to an integer n to be decided
+	n, r := new(big.Int).QuoRem(log, ln10, new(big.Int))
+	margin := new(big.Int).Rsh(ln10, 80)
+	switch {
+	case r.Cmp(margin) < 0:
+	case new(big.Int).Sub(ln10, r).Cmp(margin) < 0:
+		n.Add(n, bigOne)
+	default:
+		return n.Add(n, bigOne)
+	}
+	if log2.Cmp(big.NewFloat(compareDigitsBits)) < 0 && d.Eval().Cmp(new(big.Int).Exp(big.NewInt(10), n, nil)) < 0 {
+		return n
+	}
+	return n.Add(n, bigOne)
+}
+
+// leadRatioBits is the number of fractional bits of the ratio computed by leadRatio.
+const leadRatioBits = 192
+
+// leadRatio returns (c + f) as num / den, where c * b ^ e is the most significant monome
+// and f is the ratio of the other ones to b ^ e, with leadRatioBits fractional bits.
+// Monomes lower than b ^ (e - 1) by more than 2 ^ 64 are ignored.
+func (t terms) leadRatio(b, e *big.Int) (num, den *big.Int) {
+	den = new(big.Int).Lsh(bigOne, leadRatioBits)
+	num = new(big.Int).Lsh(t[len(t)-1].coeff, leadRatioBits)
+	for i := len(t) - 2; i >= 0; i-- {
+		// coeff / b ^ (e - exponent) is lower than 2 ^ -((e - exponent - 1) * (bits of b - 1))
+		diff := new(big.Int).Sub(e, t[i].exponent.eval(b))
+		if !diff.IsInt64() || (diff.Int64()-1)*int64(b.BitLen()-1) > leadRatioBits+64 {
+			break
+		}
+		ratio := new(big.Int).Lsh(t[i].coeff, leadRatioBits)
+		num.Add(num, ratio.Quo(ratio, new(big.Int).Exp(b, diff, nil)))
+	}
+	return num, den
+}
+
+// lnFixed returns the natural logarithm of num / den, which must be positive,
+// as a fixed-point number with prec fractional bits, i.e. multiplied by 2 ^ prec.
+// The error is a few units in the last place per bit of precision, hence 32 guard bits.
+func lnFixed(num, den *big.Int, prec uint) *big.Int {
+	// num / den = y * 2 ^ k with y in (1/2, 2): ln(num / den) = ln(y) + k * ln(2)
+	k := num.BitLen() - den.BitLen()
+	n, d := new(big.Int).Set(num), new(big.Int).Set(den)
+	if k > 0 {
+		d.Lsh(d, uint(k))
+	} else {
+		n.Lsh(n, uint(-k))
+	}
+
+	// ln(y) = 2 * atanh((y - 1) / (y + 1)) where |(y - 1) / (y + 1)| < 1/3
+	ln := atanhFixed(new(big.Int).Sub(n, d), new(big.Int).Add(n, d), prec+32)
+	if k != 0 {
+		ln2 := atanhFixed(bigOne, big.NewInt(3), prec+32)
+		ln.Add(ln, ln2.Mul(ln2, big.NewInt(int64(k))))
+	}
+	return ln.Rsh(ln, 31) // times 2, 32 guard bits dropped
+}
+
+// atanhFixed returns atanh(zn / zd) with prec fractional bits, for |zn / zd| <= 1/3:
+// each term of the series z + z^3 / 3 + z^5 / 5 + ... is at least 9 times lower than the previous one.
+func atanhFixed(zn, zd *big.Int, prec uint) *big.Int {
+	sum := new(big.Int)
+	z2n, z2d := new(big.Int).Mul(zn, zn), new(big.Int).Mul(zd, zd)
+	pow := new(big.Int).Lsh(zn, prec)
+	pow.Quo(pow, zd)
+	term := new(big.Int)
+	for i := int64(1); pow.Sign() != 0; i += 2 {
+		sum.Add(sum, term.Quo(pow, big.NewInt(i)))
+		pow.Mul(pow, z2n)
+		pow.Quo(pow, z2d)
+	}
+	return sum
+}
@@ -0,0 +1,142 @@
+package decomposition
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestEvalFloat(t *testing.T) {
+	// exact values below 2 ^ 53
+	for b := 2; b < 6; b++ {
+		for n := 0; n < 1000; n++ {
+			d, _ := New(b, n)
+			if f, _ := d.EvalFloat(0).Float64(); f != float64(n) {
+				t.Errorf("base-%v decomposition of %v: got %v", b, n, f)
+			}
+		}
+	}
+
+	// large values are close to the exact ones
+	d, _ := NewTower(2, 5) // 2 ^ 65536
+	exact := new(big.Float).SetInt(d.Eval())
+	if approx := d.EvalFloat(100); approx.Cmp(exact) != 0 {
+		t.Errorf("2 ^ 65536: expected %v, got %v", exact, approx)
+	}
+	d, _ = New(10, 123456789)
+	d = d.IncrementBase().IncrementBase()
+	exact = new(big.Float).SetPrec(200).SetInt(d.Eval())
+	approx := d.EvalFloat(200)
+	diff := new(big.Float).Sub(exact, approx)
+	diff.Quo(diff, exact)
+	if f, _ := diff.Float64(); f > 1e-50 || f < -1e-50 {
+		t.Errorf("%v: relative error %v", d, f)
+	}
+
+	// overflows
+	for _, height := range []int{5, 6, 10} {
+		d, _ := NewTower(2, height)
+		if approx := d.EvalFloat(0); (height > 5) != approx.IsInf() {
+			t.Errorf("tower of height %v: got %v", height, approx.IsInf())
+		}
+	}
+}
+
+func TestLog2(t *testing.T) {
+	// close to the exact logarithms of small values
+	for b := 2; b < 6; b++ {
+		for n := 1; n < 1000; n++ {
+			d, _ := New(b, n)
+			l, _ := d.Log2().Float64()
+			if diff := l - math.Log2(float64(n)); diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("base-%v decomposition of %v: got %v", b, n, l)
+			}
+		}
+	}
+
+	// zero
+	if l := (Decomposition{}).Log2(); !l.IsInf() || l.Sign() > 0 {
+		t.Errorf("zero: got %v", l)
+	}
+
+	// towers: the logarithm of 2 ^ 2 ^ 2 ^ 2 ^ 2 ^ 2 is 2 ^ 65536
+	d, _ := NewTower(2, 6)
+	if exp := d.Log2().MantExp(nil); exp != 65537 {
+		t.Errorf("tower of height 6: wrong logarithm %v", d.Log2())
+	}
+	d, _ = NewTower(2, 7)
+	if l := d.Log2(); !l.IsInf() {
+		t.Errorf("tower of height 7: got %v", l)
+	}
+}
+
+func TestNumDecimalDigits(t *testing.T) {
+	for _, g := range []struct {
+		b, n, digits int
+	}{
+		{2, 0, 1},
+		{2, 1, 1},
+		{3, 9, 1},
+		{3, 10, 2},
+		{2, 999, 3},
+		{2, 1000, 4},
+		{10, 123456789, 9},
+	} {
+		d, _ := New(g.b, g.n)
+		if digits := d.NumDecimalDigits(); digits.Int64() != int64(g.digits) {
+			t.Errorf("base-%v decomposition of %v: expected %v digits, got %v", g.b, g.n, g.digits, digits)
+		}
+	}
+
+	// estimated: 2 ^ 65536 has 19729 digits
+	d, _ := NewTower(2, 5)
+	if digits := d.NumDecimalDigits(); digits.Int64() != 19729 {
+		t.Errorf("2 ^ 65536: expected 19729 digits, got %v", digits)
+	}
+
+	// far above the threshold: 2 ^ (2 ^ 60) and 3 ^ (10 ^ 20)
+	for _, g := range []struct {
+		b      int
+		e      *big.Int
+		digits string
+	}{
+		{2, new(big.Int).Lsh(bigOne, 60), "347063955532709821"},
+		{3, new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil), "47712125471966243730"},
+	} {
+		e, _ := NewBig(g.b, g.e)
+		d := NewBuilder(g.b).Add(1, e).MustBuild()
+		if digits := d.NumDecimalDigits(); digits.String() != g.digits {
+			t.Errorf("%v ^ %v: expected %v digits, got %v", g.b, g.e, g.digits, digits)
+		}
+	}
+
+	// overflow
+	d, _ = NewTower(2, 7)
+	if digits := d.NumDecimalDigits(); digits != nil {
+		t.Errorf("tower of height 7: got %v digits", digits)
+	}
+}
+
+func TestNumDecimalDigitsThreshold(t *testing.T) {
+	// values around exactDigitsBits, including powers of ten and values just below them
+	ten := big.NewInt(10)
+	for _, n := range []*big.Int{
+		new(big.Int).Lsh(bigOne, exactDigitsBits-1),
+		new(big.Int).Lsh(bigOne, exactDigitsBits),
+		new(big.Int).Lsh(bigOne, exactDigitsBits+1),
+		new(big.Int).Exp(ten, big.NewInt(5000), nil),
+		new(big.Int).Sub(new(big.Int).Exp(ten, big.NewInt(5000), nil), bigOne),
+		new(big.Int).Exp(ten, big.NewInt(9000), nil),
+		new(big.Int).Sub(new(big.Int).Exp(ten, big.NewInt(9000), nil), bigOne),
+		new(big.Int).Exp(big.NewInt(7), big.NewInt(6000), nil),
+	} {
+		// (in bases with few monomes, evaluation being slow otherwise)
+		for _, b := range []int{10, 1000, 1 << 20} {
+			d, _ := NewBig(b, n)
+			expected := int64(len(n.String()))
+			if digits := d.NumDecimalDigits(); digits.Int64() != expected {
+				t.Errorf("base-%v decomposition of a %v-digit number: got %v digits", b, expected, digits)
+			}
+		}
+	}
+}
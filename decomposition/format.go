@@ -0,0 +1,295 @@
+package decomposition
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// formatter writes human-readable decompositions.
+// All monomes share the same base, multiplication symbol
+// and left and right 'groupers' around exponents.
+type formatter struct {
+	base                  string // set by format
+	times                 string // multiplication symbol, with spaces
+	power                 string // exponentiation symbol, with spaces
+	leftGroup, rightGroup string // around exponents
+	plus                  string // between top-level monomes
+	nestedPlus            string // between monomes of exponents
+	superscript           bool   // if true, simple exponents are written with superscript characters
+	numberPrefix          string // before the base and coefficients
+	numberSuffix          string // after the base and coefficients
+	ellipsis              string // replaces exponents nested too deeply
+	maxDepth              int    // maximum nesting of printed exponents, negative for no limit
+}
+
+// plainFormatter writes decompositions as String does.
+var plainFormatter = formatter{
+	times:      " * ",
+	power:      " ^ ",
+	leftGroup:  "(",
+	rightGroup: ")",
+	plus:       " + ",
+	nestedPlus: " + ",
+	ellipsis:   "...",
+	maxDepth:   -1,
+}
+
+// latexFormatter writes decompositions as LaTeX does.
+var latexFormatter = formatter{
+	times:      ` \times `,
+	power:      " ^ ",
+	leftGroup:  "{",
+	rightGroup: "}",
+	plus:       " + ",
+	nestedPlus: " + ",
+	ellipsis:   `\dots`,
+	maxDepth:   -1,
+}
+
+// typstFormatter writes decompositions as Typst does.
+var typstFormatter = formatter{
+	times:      " times ",
+	power:      "^",
+	leftGroup:  "(",
+	rightGroup: ")",
+	plus:       " + ",
+	nestedPlus: " + ",
+	ellipsis:   "dots",
+	maxDepth:   -1,
+}
+
+// FormatOptions controls how FormatWith writes a decomposition.
+// The zero value writes decompositions as String does.
+type FormatOptions struct {
+	// Times is the multiplication symbol, "*" if empty.
+	Times string
+
+	// LeftGroup and RightGroup are written around exponents,
+	// "(" and ")" if both are empty.
+	LeftGroup, RightGroup string
+
+	// Superscript writes simple exponents, i.e. sums of constants and of the base,
+	// with Unicode superscript characters instead of a caret, e.g. "3³⁺¹ + 2".
+	// Other exponents are written with a caret.
+	Superscript bool
+
+	// Compact removes the spaces around operators, e.g. "3^(3+1)+2".
+	Compact bool
+}
+
+// FormatWith returns the decomposition written according to the options.
+// For instance, String and LaTeX are equivalent to
+//
+//	d.FormatWith(FormatOptions{})
+//	d.FormatWith(FormatOptions{Times: `\times`, LeftGroup: "{", RightGroup: "}"})
+func (d Decomposition) FormatWith(opts FormatOptions) string {
+	f := plainFormatter
+	if opts.Times != "" {
+		f.times = " " + opts.Times + " "
+	}
+	if opts.LeftGroup != "" || opts.RightGroup != "" {
+		f.leftGroup, f.rightGroup = opts.LeftGroup, opts.RightGroup
+	}
+	f.superscript = opts.Superscript
+	if opts.Compact {
+		f.times = strings.TrimSpace(f.times)
+		f.power = strings.TrimSpace(f.power)
+		f.plus = strings.TrimSpace(f.plus)
+		f.nestedPlus = strings.TrimSpace(f.nestedPlus)
+	}
+	return f.format(d.base, d.monomes)
+}
+
+// format returns the base-b terms formatted by f.
+// The output is written into a single strings.Builder
+// whose capacity is estimated beforehand.
+func (f formatter) format(b *big.Int, t terms) string {
+	// zero has no base
+	if t.isZero() {
+		return "0"
+	}
+	f.base = f.numberPrefix + b.String() + f.numberSuffix
+
+	var sb strings.Builder
+	sb.Grow(f.estimate(t) + len(t)*len(f.plus))
+	f.writeTerms(&sb, t, f.plus, 0)
+	return sb.String()
+}
+
+// estimate returns an upper bound of the length of the formatted terms.
+func (f formatter) estimate(t terms) int {
+	// zero
+	if t.isZero() {
+		return 1
+	}
+
+	n := 0
+	for _, m := range t {
+		// coefficients are lower than the base so they have at most as many digits
+		n += 2*len(f.base) + len(f.times) + len(f.power) + len(f.leftGroup) + len(f.rightGroup) + len(f.nestedPlus)
+		n += f.estimate(m.exponent)
+	}
+	return n
+}
+
+// writeTerms writes the terms with most significant monomes first,
+// separated by plus. depth is the number of enclosing exponents.
+// The decomposition does not contain any spurrious '0+...', '0*...' expressions.
+func (f formatter) writeTerms(sb *strings.Builder, t terms, plus string, depth int) {
+	// if there is no monome, decompostion is zero
+	if t.isZero() {
+		sb.WriteString("0")
+		return
+	}
+
+	// write all monomes in reverse order
+	for i := len(t) - 1; i >= 0; i-- {
+		f.writeMonome(sb, t[i], depth)
+		if i > 0 {
+			sb.WriteString(plus)
+		}
+	}
+}
+
+// writeMonome writes a single monome. depth is the number of enclosing exponents.
+func (f formatter) writeMonome(sb *strings.Builder, m monome, depth int) {
+	// if monome is zero, just write 0
+	if m.isZero() {
+		sb.WriteString("0")
+		return
+	}
+
+	switch {
+	case m.exponent.isZero():
+		// base ^ exponent is one, so monome is equal to its coeff
+		f.writeCoeff(sb, m.coeff)
+
+	case m.exponent.isOne():
+		// base ^ exponent is base
+		if m.coeff.Cmp(bigOne) != 0 {
+			// 1 times base is useless, otherwise write coeff times base
+			f.writeCoeff(sb, m.coeff)
+			sb.WriteString(f.times)
+		}
+		sb.WriteString(f.base)
+
+	default:
+		// general case
+		if m.coeff.Cmp(bigOne) != 0 {
+			// 1 times ... is useless
+			f.writeCoeff(sb, m.coeff)
+			sb.WriteString(f.times)
+		}
+		sb.WriteString(f.base)
+		if f.superscript && m.exponent.isSimple() {
+			// e.g. 3³⁺¹
+			f.writeSuperscript(sb, m.exponent)
+			return
+		}
+		sb.WriteString(f.power)
+		sb.WriteString(f.leftGroup)
+		if f.maxDepth >= 0 && depth >= f.maxDepth {
+			// too deep
+			sb.WriteString(f.ellipsis)
+		} else {
+			f.writeTerms(sb, m.exponent, f.nestedPlus, depth+1)
+		}
+		sb.WriteString(f.rightGroup)
+	}
+}
+
+// isSimple returns true if the terms are a sum of a constant and (once) of the base.
+func (t terms) isSimple() bool {
+	for _, m := range t {
+		if !m.exponent.isZero() && !(m.exponent.isOne() && m.coeff.Cmp(bigOne) == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// superscripts maps the characters of simple terms to superscript characters.
+var superscripts = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+	"+", "⁺",
+)
+
+// writeSuperscript writes simple terms with superscript characters.
+func (f formatter) writeSuperscript(sb *strings.Builder, t terms) {
+	var exp strings.Builder
+	f.writeTerms(&exp, t, "+", 0)
+	superscripts.WriteString(sb, exp.String())
+}
+
+// writeCoeff writes a coefficient between the number prefix and suffix.
+func (f formatter) writeCoeff(sb *strings.Builder, coeff *big.Int) {
+	sb.WriteString(f.numberPrefix)
+	writeInt(sb, coeff)
+	sb.WriteString(f.numberSuffix)
+}
+
+// writeInt writes the decimal representation of n.
+// Small integers, i.e. almost all coefficients, are written without any allocation.
+func writeInt(sb *strings.Builder, n *big.Int) {
+	if !n.IsInt64() {
+		sb.WriteString(n.String())
+		return
+	}
+	var buf [20]byte
+	sb.Write(strconv.AppendInt(buf[:0], n.Int64(), 10))
+}
+
+// Format implements fmt.Formatter.
+// The %v and %s verbs print the decomposition as String does
+// and the %q verb prints it as a double-quoted Go string.
+// With the + flag (e.g. %+v), the decomposition is printed as LaTeX does.
+// With the # flag, %#v prints the Go expression returned by GoString.
+// The precision limits the nesting of printed exponents:
+// deeper exponents are replaced by an ellipsis, e.g. %.1v prints "2 ^ (2 ^ (...))"
+// for 2 ^ (2 ^ (2)), and %.0v prints "2 ^ (...)".
+// The width pads the output with spaces, on the right with the - flag.
+func (d Decomposition) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's', 'q':
+	default:
+		fmt.Fprintf(s, "%%!%c(decomposition.Decomposition=%v)", verb, d.String())
+		return
+	}
+
+	// Go syntax
+	if verb == 'v' && s.Flag('#') {
+		io.WriteString(s, d.GoString())
+		return
+	}
+
+	// plain or LaTeX with limited depth (or not)
+	f := plainFormatter
+	if s.Flag('+') {
+		f = latexFormatter
+	}
+	if p, ok := s.Precision(); ok {
+		f.maxDepth = p
+	}
+	str := f.format(d.base, d.monomes)
+	if verb == 'q' {
+		str = strconv.Quote(str)
+	}
+
+	// padding
+	if w, ok := s.Width(); ok {
+		if n := utf8.RuneCountInString(str); n < w {
+			pad := strings.Repeat(" ", w-n)
+			if s.Flag('-') {
+				str += pad
+			} else {
+				str = pad + str
+			}
+		}
+	}
+	io.WriteString(s, str)
+}
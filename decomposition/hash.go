@@ -0,0 +1,18 @@
+package decomposition
+
+import (
+	"hash/fnv"
+)
+
+// Hash returns a structural fingerprint of the decomposition:
+// the 64-bit FNV-1a hash of its base and monomes, encoded as MarshalBinary does.
+// Equal decompositions have the same hash, which is stable across processes
+// and platforms, so it can be used as a map key (with Equal to resolve collisions),
+// to deduplicate decompositions or to detect regressions between runs.
+// It does not evaluate the decomposition.
+func (d Decomposition) Hash() uint64 {
+	h := fnv.New64a()
+	data := appendBigInt(nil, d.base)
+	h.Write(d.monomes.appendBinary(data))
+	return h.Sum64()
+}
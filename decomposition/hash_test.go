@@ -0,0 +1,41 @@
+package decomposition
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleDecomposition_Hash() {
+	d, _ := New(2, 10)
+	fmt.Printf("%#x\n", d.Hash())
+
+	// Output:
+	// 0xc4c693f17c5f6fe
+}
+
+func TestHash(t *testing.T) {
+	seen := make(map[uint64]Decomposition)
+	for b := 2; b < 5; b++ {
+		for n := 0; n < 300; n++ {
+			d, _ := New(b, n)
+
+			// equal decompositions have the same hash
+			parsed, _ := ParseBase(b, d.String())
+			if d.Hash() != parsed.Hash() {
+				t.Errorf("base-%v decomposition of %v: hash %x, parsed %x", b, n, d.Hash(), parsed.Hash())
+			}
+
+			// and different ones do not collide here
+			if other, ok := seen[d.Hash()]; ok {
+				t.Errorf("%v and %v collide", other, d)
+			}
+			seen[d.Hash()] = d
+		}
+	}
+
+	// the default value differs from zero in any base
+	zero, _ := New(2, 0)
+	if (Decomposition{}).Hash() == zero.Hash() {
+		t.Errorf("default value and base-2 zero collide")
+	}
+}
@@ -0,0 +1,76 @@
+package decomposition
+
+// Interner stores structurally identical exponents once,
+// so that decompositions interned by the same Interner share them.
+// After decrements, runs of (b-1) * b ^ e monomes repeat the same exponents
+// many times, within a decomposition and across the steps of a sequence.
+// An Interner is not safe for concurrent use and it keeps all interned exponents
+// alive as long as it is referenced.
+type Interner struct {
+	pool  map[string]terms
+	stats InternStats
+}
+
+// InternStats reports the deduplication of an Interner.
+type InternStats struct {
+	// Lookups is the number of interned exponents.
+	Lookups int
+
+	// Hits is the number of exponents which were replaced by an identical one in the pool.
+	Hits int
+
+	// Unique is the number of distinct exponents in the pool.
+	Unique int
+
+	// SavedMonomes is the number of monomes, including nested ones,
+	// of the exponents which were replaced, i.e. which are not duplicated in memory.
+	SavedMonomes int
+}
+
+// NewInterner returns an empty Interner.
+func NewInterner() *Interner {
+	return &Interner{pool: make(map[string]terms)}
+}
+
+// Intern returns a decomposition equal to d whose exponents,
+// at all levels, are shared with identical exponents of previously interned decompositions.
+func (in *Interner) Intern(d Decomposition) Decomposition {
+	if d.IsZero() {
+		return d
+	}
+	monomes := make(terms, len(d.monomes))
+	for i, m := range d.monomes {
+		monomes[i] = monome{m.coeff, in.intern(m.exponent)}
+	}
+	return newDecomposition(d.base, monomes)
+}
+
+// Stats returns the deduplication statistics of the Interner.
+func (in *Interner) Stats() InternStats {
+	return in.stats
+}
+
+// intern returns the terms of the pool identical to t, adding them if there are none.
+// Exponents are interned first so that terms added to the pool only share interned exponents.
+func (in *Interner) intern(t terms) terms {
+	if t.isZero() {
+		return nil
+	}
+	in.stats.Lookups++
+
+	// identical terms have the same binary encoding
+	key := string(t.appendBinary(nil))
+	if interned, ok := in.pool[key]; ok {
+		in.stats.Hits++
+		in.stats.SavedMonomes += t.size()
+		return interned
+	}
+
+	interned := make(terms, len(t))
+	for i, m := range t {
+		interned[i] = monome{m.coeff, in.intern(m.exponent)}
+	}
+	in.pool[key] = interned
+	in.stats.Unique++
+	return interned
+}
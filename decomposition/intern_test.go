@@ -0,0 +1,41 @@
+package decomposition
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleInterner() {
+	in := NewInterner()
+	d, _ := NewPower(10, 12)
+	for i := 0; i < 3; i++ {
+		d = in.Intern(d.Decrement())
+	}
+	fmt.Printf("%+v\n", in.Stats())
+
+	// Output:
+	// {Lookups:35 Hits:24 Unique:11 SavedMonomes:30}
+}
+
+func TestInterner(t *testing.T) {
+	in := NewInterner()
+	for n := 0; n < 300; n++ {
+		d, _ := New(3, n)
+		interned := in.Intern(d)
+		if !interned.Equal(d) {
+			t.Errorf("interned decomposition of %v: expected %v, got %v", n, d, interned)
+		}
+	}
+	stats := in.Stats()
+	if stats.Lookups != stats.Hits+stats.Unique {
+		t.Errorf("inconsistent statistics %+v", stats)
+	}
+
+	// identical exponents are shared
+	d1, _ := New(3, 2*27)
+	d2, _ := New(3, 27+2)
+	i1, i2 := in.Intern(d1), in.Intern(d2)
+	if &i1.monomes[0].exponent[0] != &i2.monomes[1].exponent[0] {
+		t.Errorf("exponent 3 is not shared")
+	}
+}
@@ -0,0 +1,114 @@
+package decomposition
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// jsonVersion is the version of the JSON encoding of decompositions.
+// It is incremented whenever the encoding changes,
+// older versions remaining decodable.
+const jsonVersion = 1
+
+// jsonDecomposition is the JSON encoding of a Decomposition.
+type jsonDecomposition struct {
+	Version int          `json:"version"`
+	Base    *big.Int     `json:"base"`
+	Monomes []jsonMonome `json:"monomes"`
+}
+
+// jsonMonome is the JSON encoding of a monome.
+// Its base is the one of the enclosing decomposition.
+// A zero exponent is omitted.
+type jsonMonome struct {
+	Coeff    *big.Int     `json:"coeff"`
+	Exponent []jsonMonome `json:"exponent,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The decomposition is encoded as an object with its base
+// and its monomes from the most significant to the least significant one,
+// each of them with a coeff and the monomes of its exponent, e.g.
+//
+//	{
+//	  "version": 1,
+//	  "base": 2,
+//	  "monomes": [
+//	    {"coeff": 1, "exponent": [{"coeff": 1, "exponent": [{"coeff": 1}]}]},
+//	    {"coeff": 1}
+//	  ]
+//	}
+//
+// for 2 ^ (2) + 1 (indented here for readability).
+// Bases and coefficients are JSON numbers of any size.
+func (d Decomposition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonDecomposition{
+		Version: jsonVersion,
+		Base:    d.Base(), // 0 for the default value
+		Monomes: toJSON(d.monomes),
+	})
+}
+
+// toJSON returns the JSON encoding of the terms, most significant monome first.
+func toJSON(t terms) []jsonMonome {
+	monomes := make([]jsonMonome, len(t))
+	for i, m := range t {
+		monomes[len(t)-1-i] = jsonMonome{
+			Coeff:    m.coeff,
+			Exponent: toJSON(m.exponent),
+		}
+	}
+	return monomes
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The decoded decomposition is validated like the ones built with Builder
+// and must not exceed DefaultLimits.
+func (d *Decomposition) UnmarshalJSON(data []byte) error {
+	decoded, err := UnmarshalJSONWith(data, ParseOptions{})
+	if err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// UnmarshalJSONWith is like Decomposition.UnmarshalJSON
+// but the decomposition is read according to the options.
+// The base of the options is ignored since the encoding holds the base.
+func UnmarshalJSONWith(data []byte, opts ParseOptions) (Decomposition, error) {
+	var j jsonDecomposition
+	if err := json.Unmarshal(data, &j); err != nil {
+		return Decomposition{}, err
+	}
+	if j.Version != jsonVersion {
+		return Decomposition{}, fmt.Errorf("unsupported JSON encoding version %v", j.Version)
+	}
+
+	// the default value of Decomposition has no base
+	if j.Base != nil && j.Base.Sign() == 0 && len(j.Monomes) == 0 {
+		return Decomposition{}, nil
+	}
+
+	return fromJSON(j.Base, j.Monomes, opts)
+}
+
+// fromJSON builds the base-b decomposition encoded by the monomes, according to the options.
+func fromJSON(b *big.Int, monomes []jsonMonome, opts ParseOptions) (Decomposition, error) {
+	if b == nil {
+		return Decomposition{}, fmt.Errorf("missing base")
+	}
+	builder := opts.builder(b)
+	for _, m := range monomes {
+		exp, err := fromJSON(b, m.Exponent, opts)
+		if err != nil {
+			return Decomposition{}, err
+		}
+		if m.Coeff == nil {
+			return Decomposition{}, fmt.Errorf("missing coefficient")
+		}
+		builder.AddBig(m.Coeff, exp)
+	}
+	return builder.Build()
+}
@@ -0,0 +1,59 @@
+package decomposition
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func ExampleDecomposition_MarshalJSON() {
+	d, _ := New(2, 5)
+	b, _ := json.Marshal(d)
+	fmt.Println(string(b))
+
+	// Output:
+	// {"version":1,"base":2,"monomes":[{"coeff":1,"exponent":[{"coeff":1,"exponent":[{"coeff":1}]}]},{"coeff":1}]}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 100; i++ {
+		d := Rand(2+i%5, 3, rng)
+		b, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("cannot marshal %q: %v", d, err)
+		}
+		var decoded Decomposition
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("cannot unmarshal %s: %v", b, err)
+		}
+		if !decoded.Equal(d) {
+			t.Errorf("%q became %q", d, decoded)
+		}
+	}
+
+	// default value
+	var decoded Decomposition
+	b, _ := json.Marshal(Decomposition{})
+	if err := json.Unmarshal(b, &decoded); err != nil || !decoded.Equal(Decomposition{}) {
+		t.Errorf("default value became %q (base %v): %v", decoded, decoded.Base(), err)
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	for _, s := range []string{
+		`{"version":2,"base":2,"monomes":[]}`,                        // unknown version
+		`{"version":1,"monomes":[{"coeff":1}]}`,                      // missing base
+		`{"version":1,"base":1,"monomes":[{"coeff":1}]}`,             // base too small
+		`{"version":1,"base":2,"monomes":[{"coeff":2}]}`,             // coefficient too large
+		`{"version":1,"base":2,"monomes":[{}]}`,                      // missing coefficient
+		`{"version":1,"base":3,"monomes":[{"coeff":1},{"coeff":2}]}`, // same exponent
+		`{"version":1,"base":2,"monomes":[{"coeff":"1"}]}`,           // not a number
+	} {
+		var d Decomposition
+		if err := json.Unmarshal([]byte(s), &d); err == nil {
+			t.Errorf("invalid %s decoded as %q", s, d)
+		}
+	}
+}
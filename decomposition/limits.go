@@ -0,0 +1,110 @@
+package decomposition
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooDeep is returned when the exponents of a decomposition
+// are nested more than allowed by the limits.
+var ErrTooDeep = errors.New("decomposition too deep")
+
+// ErrTooLarge is returned when a decomposition
+// has more monomes or a larger base than allowed by the limits.
+var ErrTooLarge = errors.New("decomposition too large")
+
+// Limits bounds the size of decompositions
+// so that adversarial or unexpectedly huge inputs fail with a clear error
+// instead of consuming all memory or overflowing the stack.
+// A zero field means no limit.
+type Limits struct {
+	// MaxDepth is the maximum nesting depth of exponents.
+	// A constant has depth 1, b ^ c has depth 2 if c is a constant, etc.
+	MaxDepth int
+
+	// MaxSize is the maximum number of monomes,
+	// including the ones of all nested exponents.
+	MaxSize int
+
+	// MaxBits is the maximum number of bits of the base,
+	// hence of the coefficients which are lower than the base.
+	MaxBits int
+}
+
+// DefaultLimits returns the limits used by Builder, the parsers and the decoders
+// when none are given.
+// It returns a new value at each call so that callers cannot change the limits of others:
+// per-call limits are given to Builder.WithLimits or in ParseOptions.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxDepth: 10000,
+		MaxSize:  10000000,
+		MaxBits:  1 << 20,
+	}
+}
+
+// Check returns an error wrapping ErrTooDeep or ErrTooLarge
+// if the decomposition exceeds the limits.
+func (l Limits) Check(d Decomposition) error {
+	if l.MaxBits > 0 && d.base != nil && d.base.BitLen() > l.MaxBits {
+		return fmt.Errorf("%w: base exceeds %v bits", ErrTooLarge, l.MaxBits)
+	}
+	if l.MaxDepth > 0 && d.monomes.exceedsDepth(l.MaxDepth) {
+		return fmt.Errorf("%w: depth exceeds %v", ErrTooDeep, l.MaxDepth)
+	}
+	if l.MaxSize > 0 && d.monomes.size() > l.MaxSize {
+		return fmt.Errorf("%w: size exceeds %v", ErrTooLarge, l.MaxSize)
+	}
+	return nil
+}
+
+// checkDigits returns an error wrapping ErrTooLarge if a decimal number
+// of the given number of digits certainly has more than MaxBits bits.
+// It is checked before the number is converted, which is not linear in its number of digits.
+func (l Limits) checkDigits(digits int) error {
+	// a number of n digits has at least (n-1) * log2(10) > 3 * (n-1) bits
+	if l.MaxBits > 0 && digits > l.MaxBits/3+1 {
+		return fmt.Errorf("%w: number of %v digits exceeds %v bits", ErrTooLarge, digits, l.MaxBits)
+	}
+	return nil
+}
+
+// DecrementWithin is like Decrement but it returns an error wrapping ErrTooLarge
+// if the decremented decomposition would have more monomes than allowed by the limits.
+// Decrementing b ^ e produces e monomes which may be far too many
+// to fit in memory when e is itself a large decomposition.
+// The expansion is aborted as soon as the limit is reached.
+func (d Decomposition) DecrementWithin(l Limits) (Decomposition, error) {
+	decremented, err := d.monomes.decrement(d.base, l.MaxSize)
+	if err != nil {
+		return Decomposition{}, err
+	}
+	return newDecomposition(d.base, decremented), nil
+}
+
+// size returns the number of monomes,
+// including the ones of all nested exponents.
+func (t terms) size() int {
+	n := len(t)
+	for _, m := range t {
+		n += m.exponent.size()
+	}
+	return n
+}
+
+// exceedsDepth returns true if the terms are nested more than max times.
+// It stops as soon as the maximum depth is reached.
+func (t terms) exceedsDepth(max int) bool {
+	if t.isZero() {
+		return false
+	}
+	if max == 0 {
+		return true
+	}
+	for _, m := range t {
+		if m.exponent.exceedsDepth(max - 1) {
+			return true
+		}
+	}
+	return false
+}
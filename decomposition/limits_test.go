@@ -0,0 +1,116 @@
+package decomposition
+
+import (
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestLimitsCheck(t *testing.T) {
+	// 2 ^ (2 ^ (2)) has depth 4 and size 4 since 2 is 2 ^ 1
+	d, _ := New(2, 16)
+	for _, g := range []struct {
+		l   Limits
+		err error
+	}{
+		{Limits{}, nil},
+		{Limits{MaxDepth: 4, MaxSize: 4}, nil},
+		{Limits{MaxDepth: 3}, ErrTooDeep},
+		{Limits{MaxSize: 3}, ErrTooLarge},
+		{Limits{MaxBits: 2}, nil},
+		{Limits{MaxBits: 1}, ErrTooLarge},
+	} {
+		if err := g.l.Check(d); !errors.Is(err, g.err) {
+			t.Errorf("checking %+v: got error %v, expecting %v", g.l, err, g.err)
+		}
+	}
+}
+
+func TestDecrementWithin(t *testing.T) {
+	// 3 ^ (3 ^ (3)) - 1 has 27 monomes with coefficient 2
+	d, _ := New(3, 27)
+	tower := NewBuilder(3).Add(1, d).MustBuild()
+
+	if _, err := tower.DecrementWithin(Limits{MaxSize: 20}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	}
+
+	decremented, err := tower.DecrementWithin(Limits{MaxSize: 1000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decremented.String() != tower.Decrement().String() {
+		t.Errorf("got %q, expecting %q", decremented, tower.Decrement())
+	}
+}
+
+func TestBuilderLimits(t *testing.T) {
+	d, _ := New(2, 16)
+	if _, err := NewBuilder(2).Add(1, d).WithLimits(Limits{MaxDepth: 4}).Build(); !errors.Is(err, ErrTooDeep) {
+		t.Errorf("expecting ErrTooDeep, got %v", err)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	// 2 ^ (2 ^ (2)) has depth 4 and size 4
+	d, _ := New(2, 16)
+	text, _ := d.MarshalText()
+	data, _ := d.MarshalBinary()
+	j, _ := d.MarshalJSON()
+	x, _ := xml.Marshal(d)
+	decoders := map[string]func(ParseOptions) (Decomposition, error){
+		"ParseWith":           func(o ParseOptions) (Decomposition, error) { return ParseWith(d.String(), o) },
+		"UnmarshalTextWith":   func(o ParseOptions) (Decomposition, error) { return UnmarshalTextWith(text, o) },
+		"UnmarshalBinaryWith": func(o ParseOptions) (Decomposition, error) { return UnmarshalBinaryWith(data, o) },
+		"UnmarshalJSONWith":   func(o ParseOptions) (Decomposition, error) { return UnmarshalJSONWith(j, o) },
+		"UnmarshalXMLWith": func(o ParseOptions) (Decomposition, error) {
+			dec := xml.NewDecoder(strings.NewReader(string(x)))
+			start, _ := dec.Token()
+			return UnmarshalXMLWith(dec, start.(xml.StartElement), o)
+		},
+	}
+	for name, decode := range decoders {
+		for _, g := range []struct {
+			l   Limits
+			err error
+		}{
+			{Limits{MaxDepth: 4, MaxSize: 4, MaxBits: 2}, nil},
+			{Limits{MaxDepth: 3}, ErrTooDeep},
+			{Limits{MaxSize: 3}, ErrTooLarge},
+			{Limits{MaxBits: 1}, ErrTooLarge},
+		} {
+			decoded, err := decode(ParseOptions{Limits: &g.l})
+			if !errors.Is(err, g.err) {
+				t.Errorf("%v with %+v: got error %v, expecting %v", name, g.l, err, g.err)
+			}
+			if err == nil && !decoded.Equal(d) {
+				t.Errorf("%v with %+v: got %q, expecting %q", name, g.l, decoded, d)
+			}
+		}
+	}
+}
+
+func TestParseHugeNumber(t *testing.T) {
+	// numbers beyond DefaultLimits are rejected before being converted
+	s := "1" + strings.Repeat("0", DefaultLimits().MaxBits)
+	if _, err := Parse(s); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	}
+	if _, err := UnmarshalTextWith([]byte(s+":1"), ParseOptions{}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	}
+
+	// and so are bases of binary decompositions
+	b := new(big.Int).Lsh(bigOne, 100)
+	d, _ := NewBig(2, big.NewInt(1))
+	d, _ = d.WithBaseBig(b)
+	data, _ := d.MarshalBinary()
+	if _, err := UnmarshalBinaryWith(data, ParseOptions{Limits: &Limits{MaxBits: 100}}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	}
+	if _, err := UnmarshalBinaryWith(data, ParseOptions{Limits: &Limits{MaxBits: 101}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package decomposition
+
+import (
+	"math/big"
+)
+
+// Depth returns the nesting depth of the exponents of the decomposition,
+// as defined by Limits: 0 is the only decomposition of depth 0,
+// a constant has depth 1, b ^ c has depth 2 if c is a constant, etc.
+// It is the height of the tower of exponents.
+func (d Decomposition) Depth() int {
+	return d.monomes.depth()
+}
+
+// depth returns the nesting depth of the terms.
+func (t terms) depth() int {
+	if t.isZero() {
+		return 0
+	}
+	max := 0
+	for _, m := range t {
+		if depth := m.exponent.depth(); depth > max {
+			max = depth
+		}
+	}
+	return max + 1
+}
+
+// NumTerms returns the number of top-level monomes of the decomposition,
+// 0 for a zero decomposition.
+func (d Decomposition) NumTerms() int {
+	return len(d.monomes)
+}
+
+// Size returns the number of monomes of the decomposition,
+// including the ones of all nested exponents, as bounded by Limits.
+// It measures the size of the representation without evaluating it.
+func (d Decomposition) Size() int {
+	return d.monomes.size()
+}
+
+// MaxCoefficient returns the largest coefficient of the decomposition,
+// including the ones of all nested exponents, or 0 for a zero decomposition.
+// It is lower than the base if the decomposition is valid.
+// The returned value is a copy which can be freely modified.
+func (d Decomposition) MaxCoefficient() *big.Int {
+	max := new(big.Int)
+	if c := d.monomes.maxCoeff(); c != nil {
+		max.Set(c)
+	}
+	return max
+}
+
+// maxCoeff returns the largest coefficient of the terms, nil if there is none.
+func (t terms) maxCoeff() *big.Int {
+	var max *big.Int
+	for _, m := range t {
+		if max == nil || m.coeff.Cmp(max) > 0 {
+			max = m.coeff
+		}
+		if c := m.exponent.maxCoeff(); c != nil && c.Cmp(max) > 0 {
+			max = c
+		}
+	}
+	return max
+}
@@ -0,0 +1,73 @@
+package decomposition
+
+import (
+	"testing"
+)
+
+func TestDepth(t *testing.T) {
+	for _, g := range []struct {
+		b, n, depth int
+	}{
+		{2, 0, 0},
+		{2, 1, 1},
+		{3, 2, 1},
+		{2, 2, 2},
+		{2, 3, 2},
+		{2, 4, 3},
+		{2, 16, 4},
+		{2, 16 + 2, 4},
+		{3, 3 * 3 * 3, 3},
+	} {
+		d, _ := New(g.b, g.n)
+		if depth := d.Depth(); depth != g.depth {
+			t.Errorf("depth of base-%v decomposition of %v: expected %v, got %v", g.b, g.n, g.depth, depth)
+		}
+		// consistent with limits
+		if err := (Limits{MaxDepth: g.depth}).Check(d); g.depth > 0 && err != nil {
+			t.Errorf("base-%v decomposition of %v exceeds its own depth: %v", g.b, g.n, err)
+		}
+		if err := (Limits{MaxDepth: g.depth - 1}).Check(d); g.depth > 1 && err == nil {
+			t.Errorf("base-%v decomposition of %v does not exceed depth %v", g.b, g.n, g.depth-1)
+		}
+	}
+}
+
+func TestNumTermsAndSize(t *testing.T) {
+	for _, g := range []struct {
+		b, n           int
+		numTerms, size int
+	}{
+		{2, 0, 0, 0},
+		{2, 1, 1, 1},
+		{2, 2, 1, 2},
+		{2, 3, 2, 3},
+		{2, 16 + 2, 2, 6},
+		{3, 2*81 + 3 + 2, 3, 7},
+	} {
+		d, _ := New(g.b, g.n)
+		if n := d.NumTerms(); n != g.numTerms {
+			t.Errorf("number of terms of base-%v decomposition of %v: expected %v, got %v", g.b, g.n, g.numTerms, n)
+		}
+		if n := d.Size(); n != g.size {
+			t.Errorf("size of base-%v decomposition of %v: expected %v, got %v", g.b, g.n, g.size, n)
+		}
+	}
+}
+
+func TestMaxCoefficient(t *testing.T) {
+	for _, g := range []struct {
+		b, n, max int
+	}{
+		{2, 0, 0},
+		{3, 2, 2},
+		{3, 3, 1},
+		{3, 2 * 27, 2},
+		{5, 4 + 5*3, 4},
+		{5, 5 * 5 * 5 * 5 * 5 * 5 * 5, 2}, // 5 ^ (5 + 2)
+	} {
+		d, _ := New(g.b, g.n)
+		if max := d.MaxCoefficient(); max.Int64() != int64(g.max) {
+			t.Errorf("max coefficient of base-%v decomposition of %v: expected %v, got %v", g.b, g.n, g.max, max)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package decomposition
+
+import (
+	"iter"
+	"math/big"
+)
+
+// Monome is a monome of a decomposition: coeff * base ^ exponent,
+// where the exponent is itself a decomposition in the same base.
+type Monome struct {
+	base *big.Int
+	m    monome
+}
+
+// Coeff returns the coefficient of the monome.
+// The returned value is a copy which can be freely modified.
+func (m Monome) Coeff() *big.Int {
+	return new(big.Int).Set(m.m.coeff)
+}
+
+// Base returns the base of the monome, which is the one of its decomposition.
+// The returned value is a copy which can be freely modified.
+func (m Monome) Base() *big.Int {
+	return new(big.Int).Set(m.base)
+}
+
+// Exponent returns the exponent of the monome as a decomposition in the same base.
+func (m Monome) Exponent() Decomposition {
+	return newDecomposition(m.base, m.m.exponent)
+}
+
+// Terms returns the monomes of the decomposition from the most to the least significant one.
+// A zero decomposition has no monome.
+func (d Decomposition) Terms() iter.Seq[Monome] {
+	return func(yield func(Monome) bool) {
+		for i := len(d.monomes) - 1; i >= 0; i-- {
+			if !yield(Monome{d.base, d.monomes[i]}) {
+				return
+			}
+		}
+	}
+}
+
+// Walk traverses the decomposition depth-first, most significant monomes first.
+// fn is called on each monome before the monomes of its exponent;
+// depth is the number of enclosing exponents, 0 for top-level monomes.
+// If fn returns false, the monomes of the exponent are skipped.
+func (d Decomposition) Walk(fn func(depth int, m Monome) bool) {
+	walk(d.base, d.monomes, 0, fn)
+}
+
+// walk calls fn on base-b terms at the given depth and on their exponents.
+func walk(b *big.Int, t terms, depth int, fn func(int, Monome) bool) {
+	for i := len(t) - 1; i >= 0; i-- {
+		if fn(depth, Monome{b, t[i]}) {
+			walk(b, t[i].exponent, depth+1, fn)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package decomposition
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleDecomposition_Terms() {
+	d, _ := New(3, 2*81+3+2)
+	for m := range d.Terms() {
+		fmt.Printf("%v * %v ^ (%v)\n", m.Coeff(), m.Base(), m.Exponent())
+	}
+
+	// Output:
+	// 2 * 3 ^ (3 + 1)
+	// 1 * 3 ^ (1)
+	// 2 * 3 ^ (0)
+}
+
+func TestTermsZero(t *testing.T) {
+	var d Decomposition
+	for m := range d.Terms() {
+		t.Errorf("unexpected monome %v", m.Coeff())
+	}
+}
+
+func TestTermsBreak(t *testing.T) {
+	d, _ := New(2, 7)
+	n := 0
+	for range d.Terms() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("expected 1 monome, got %v", n)
+	}
+}
+
+func ExampleDecomposition_Walk() {
+	d, _ := New(2, 10)
+	d.Walk(func(depth int, m Monome) bool {
+		fmt.Printf("%v%v * %v ^ (%v)\n", strings.Repeat(". ", depth), m.Coeff(), m.Base(), m.Exponent())
+		return true
+	})
+
+	// Output:
+	// 1 * 2 ^ (2 + 1)
+	// . 1 * 2 ^ (1)
+	// . . 1 * 2 ^ (0)
+	// . 1 * 2 ^ (0)
+	// 1 * 2 ^ (1)
+	// . 1 * 2 ^ (0)
+}
+
+func TestWalkSkip(t *testing.T) {
+	d, _ := New(2, 16+2)
+	n := 0
+	d.Walk(func(depth int, m Monome) bool {
+		if depth > 0 {
+			t.Errorf("unexpected monome at depth %v", depth)
+		}
+		n++
+		return false
+	})
+	if n != 2 {
+		t.Errorf("expected 2 top-level monomes, got %v", n)
+	}
+}
@@ -0,0 +1,31 @@
+package decomposition
+
+import (
+	"math"
+
+	"github.com/batiazinga/goodstein/ordinal"
+)
+
+// ToOrdinal returns the ordinal obtained by replacing the base by ω,
+// e.g. ω ^ (ω + 1) + ω for 2 ^ (2 + 1) + 2.
+// Each Goodstein step strictly decreases this ordinal,
+// which is why every Goodstein sequence terminates.
+// It panics if a coefficient does not fit in an int.
+func (d Decomposition) ToOrdinal() ordinal.Ordinal {
+	return d.monomes.toOrdinal()
+}
+
+// toOrdinal returns the ordinal of the terms, base replaced by ω.
+func (t terms) toOrdinal() ordinal.Ordinal {
+	// sum from the most significant monome,
+	// since lower monomes would be absorbed otherwise
+	var a ordinal.Ordinal
+	for i := len(t) - 1; i >= 0; i-- {
+		m := t[i]
+		if !m.coeff.IsInt64() || m.coeff.Int64() > math.MaxInt {
+			panic("decomposition: coefficient " + m.coeff.String() + " does not fit in an ordinal")
+		}
+		a = a.Add(ordinal.Term(m.exponent.toOrdinal(), int(m.coeff.Int64())))
+	}
+	return a
+}
@@ -0,0 +1,48 @@
+package decomposition
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleDecomposition_ToOrdinal() {
+	d, _ := New(2, 10)
+	fmt.Println(d)
+	fmt.Println(d.ToOrdinal())
+	// Output:
+	// 2 ^ (2 + 1) + 2
+	// ω ^ (ω + 1) + ω
+}
+
+func TestToOrdinal(t *testing.T) {
+	for _, g := range []struct {
+		b, n     int
+		expected string
+	}{
+		{2, 0, "0"},
+		{3, 2, "2"},
+		{3, 3, "ω"},
+		{3, 7, "ω * 2 + 1"},
+		{2, 4, "ω ^ (ω)"},
+		{3, 27 + 2*9 + 1, "ω ^ (ω) + ω ^ (2) * 2 + 1"},
+	} {
+		d, _ := New(g.b, g.n)
+		if s := d.ToOrdinal().String(); s != g.expected {
+			t.Errorf("ordinal of base-%v decomposition of %v: got %q, expecting %q", g.b, g.n, s, g.expected)
+		}
+	}
+}
+
+func TestToOrdinalDecreases(t *testing.T) {
+	// ordinals strictly decrease along Goodstein sequences
+	for n := 1; n < 16; n++ {
+		d, _ := New(2, n)
+		for i := 0; i < 20 && !d.IsZero(); i++ {
+			next := d.IncrementBase().Decrement()
+			if next.ToOrdinal().Cmp(d.ToOrdinal()) >= 0 {
+				t.Errorf("ordinal of %q is not lower than ordinal of %q", next, d)
+			}
+			d = next
+		}
+	}
+}
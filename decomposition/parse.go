@@ -0,0 +1,342 @@
+package decomposition
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// Parse parses a hereditary decomposition
+// as printed by Decomposition.String or Decomposition.LaTeX,
+// e.g. "2 ^ (2 + 1) + 2".
+// The base is inferred from the expression:
+// when several bases are possible (e.g. "2" may be 2 in base 3 or 2 ^ 1 in base 2),
+// the smallest one is chosen. Use ParseBase when the base is known.
+// The decomposition is validated like the ones built with Builder
+// and must not exceed DefaultLimits.
+// The nesting depth and the size of numbers are checked while parsing,
+// so that adversarial input cannot overflow the stack.
+func Parse(s string) (Decomposition, error) {
+	return ParseWith(s, ParseOptions{})
+}
+
+// ParseBase is like Parse but the decomposition is a base-b one.
+// It returns an error if the expression contains another base.
+func ParseBase(b int, s string) (Decomposition, error) {
+	return ParseBaseBig(big.NewInt(int64(b)), s)
+}
+
+// ParseBaseBig is like ParseBase but the base is a *big.Int.
+// b is not modified, nor retained.
+func ParseBaseBig(b *big.Int, s string) (Decomposition, error) {
+	return ParseWith(s, ParseOptions{Base: b})
+}
+
+// ParseOptions controls how ParseWith and the Unmarshal...With functions
+// read decompositions. The zero value reads them like Parse and the Unmarshal methods.
+type ParseOptions struct {
+	// Base is the base of parsed decompositions, inferred from the expression if nil.
+	// It is ignored by encodings which hold their base.
+	Base *big.Int
+
+	// Limits are the limits decompositions must not exceed, DefaultLimits if nil.
+	Limits *Limits
+
+	// Lenient normalizes decompositions instead of rejecting them, like Builder.Lenient:
+	// zero coefficients are ignored, monomes sharing the same exponent are summed
+	// and coefficients greater than or equal to the base are carried over,
+	// e.g. "2 * 3 + 2 * 3 + 5" is read as 3 ^ (2) + 2 * 3 + 2 in base 3.
+	Lenient bool
+}
+
+// limits returns the limits decompositions must not exceed.
+func (o ParseOptions) limits() Limits {
+	if o.Limits == nil {
+		return DefaultLimits()
+	}
+	return *o.Limits
+}
+
+// builder returns a Builder of base-b decompositions following the options.
+func (o ParseOptions) builder(b *big.Int) *Builder {
+	bd := NewBuilderBig(b).WithLimits(o.limits())
+	if o.Lenient {
+		bd.Lenient()
+	}
+	return bd
+}
+
+// ParseWith is like Parse but the decomposition is read according to the options.
+// The base, if any, is not modified, nor retained.
+func ParseWith(s string, opts ParseOptions) (Decomposition, error) {
+	if opts.Base == nil {
+		return parse(s, sum.base, opts)
+	}
+	if opts.Base.Cmp(bigOne) <= 0 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, opts.Base)
+	}
+	return parse(s, fixedBase(new(big.Int).Set(opts.Base)), opts)
+}
+
+// fixedBase returns a function returning b whatever the sum, to parse base-b decompositions.
+func fixedBase(b *big.Int) func(sum) *big.Int {
+	return func(sum) *big.Int { return b }
+}
+
+// parse parses a decomposition whose base is returned by base from the parsed sum,
+// according to the options.
+// Numbers are read as *big.Int so that bases and coefficients beyond int are supported.
+func parse(s string, base func(sum) *big.Int, opts ParseOptions) (Decomposition, error) {
+	p := parser{tokens: tokenize(s), limits: opts.limits()}
+	sum, err := p.parseSum(1)
+	if err != nil {
+		return Decomposition{}, err
+	}
+	if p.pos != len(p.tokens) {
+		return Decomposition{}, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	b := base(sum)
+	if b.Cmp(big.NewInt(2)) < 0 {
+		return Decomposition{}, fmt.Errorf("%w: %v", ErrBaseTooSmall, b)
+	}
+	return sum.decomposition(b, opts)
+}
+
+// tokenize splits an expression into numbers, operators and groupers.
+// The LaTeX multiplication symbol is turned into '*' and line breaks are ignored.
+func tokenize(s string) []string {
+	s = strings.ReplaceAll(s, `\times`, "*")
+	s = strings.ReplaceAll(s, `\allowbreak`, "")
+
+	var tokens []string
+	for i := 0; i < len(s); {
+		switch c := rune(s[i]); {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(s) && unicode.IsDigit(rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			tokens = append(tokens, s[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+// parser is a recursive descent parser of hereditary decompositions.
+type parser struct {
+	tokens []string
+	pos    int
+	limits Limits
+}
+
+// sum is a parsed sum of terms,
+// from the most significant to the least significant one.
+type sum []parsedTerm
+
+// parsedTerm is a parsed 'coeff * base ^ exponent' expression.
+// Any part may be missing. A bare number is either a constant or the base.
+type parsedTerm struct {
+	coeff    *big.Int // 1 if missing
+	base     *big.Int // nil for a bare number
+	number   *big.Int // value of a bare number
+	exponent *sum     // nil if missing
+}
+
+// next returns the next token, or an empty string at the end.
+func (p *parser) next() string {
+	if p.pos == len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// parseSum parses terms separated by '+', nested at the given depth.
+func (p *parser) parseSum(depth int) (sum, error) {
+	var s sum
+	for {
+		t, err := p.parseTerm(depth)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, t)
+
+		if p.next() != "+" {
+			return s, nil
+		}
+		p.pos++
+	}
+}
+
+// parseTerm parses a single term nested at the given depth.
+func (p *parser) parseTerm(depth int) (parsedTerm, error) {
+	n, err := p.parseNumber()
+	if err != nil {
+		return parsedTerm{}, err
+	}
+
+	switch p.next() {
+	case "*":
+		// coeff * base [^ exponent]
+		p.pos++
+		b, err := p.parseNumber()
+		if err != nil {
+			return parsedTerm{}, err
+		}
+		t := parsedTerm{coeff: n, base: b}
+		if p.next() == "^" {
+			p.pos++
+			if t.exponent, err = p.parseExponent(depth + 1); err != nil {
+				return parsedTerm{}, err
+			}
+		}
+		return t, nil
+
+	case "^":
+		// base ^ exponent
+		p.pos++
+		exp, err := p.parseExponent(depth + 1)
+		if err != nil {
+			return parsedTerm{}, err
+		}
+		return parsedTerm{coeff: bigOne, base: n, exponent: exp}, nil
+
+	default:
+		// constant or base
+		return parsedTerm{coeff: bigOne, number: n}, nil
+	}
+}
+
+// parseExponent parses a grouped sum or a single number nested at the given depth.
+// It returns an error wrapping ErrTooDeep if the depth exceeds the limits.
+func (p *parser) parseExponent(depth int) (*sum, error) {
+	if p.limits.MaxDepth > 0 && depth > p.limits.MaxDepth {
+		return nil, fmt.Errorf("%w: depth exceeds %v", ErrTooDeep, p.limits.MaxDepth)
+	}
+
+	var closing string
+	switch p.next() {
+	case "(":
+		closing = ")"
+	case "{":
+		closing = "}"
+	default:
+		t, err := p.parseTerm(depth)
+		if err != nil {
+			return nil, err
+		}
+		return &sum{t}, nil
+	}
+
+	p.pos++
+	s, err := p.parseSum(depth)
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != closing {
+		return nil, fmt.Errorf("missing %q", closing)
+	}
+	p.pos++
+	return &s, nil
+}
+
+// parseNumber parses a non negative integer.
+// It returns an error wrapping ErrTooLarge if it has too many digits for the limits.
+func (p *parser) parseNumber() (*big.Int, error) {
+	tok := p.next()
+	if tok == "" {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	if err := p.limits.checkDigits(len(tok)); err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(tok, 10)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %q, expecting a number", tok)
+	}
+	p.pos++
+	return n, nil
+}
+
+// base returns the smallest base consistent with the sum.
+func (s sum) base() *big.Int {
+	// explicit bases
+	b := s.explicitBase()
+	if b != nil {
+		return b
+	}
+
+	// only bare numbers: all but the last one must be the base
+	if len(s) > 1 {
+		return s[0].number
+	}
+
+	// a single number n is either n ^ 1 or a constant
+	if two := big.NewInt(2); s[0].number.Cmp(two) < 0 {
+		return two
+	}
+	return s[0].number
+}
+
+// explicitBase returns the base appearing in a 'coeff * base' or 'base ^ exponent' term.
+// It returns nil if there is no such term.
+func (s sum) explicitBase() *big.Int {
+	for _, t := range s {
+		if t.base != nil {
+			return t.base
+		}
+		if t.exponent != nil {
+			if b := t.exponent.explicitBase(); b != nil {
+				return b
+			}
+		}
+	}
+	return nil
+}
+
+// decomposition returns the base-b decomposition denoted by the sum,
+// built according to the options.
+func (s sum) decomposition(b *big.Int, opts ParseOptions) (Decomposition, error) {
+	// zero
+	if len(s) == 1 && s[0].base == nil && s[0].number.Sign() == 0 {
+		return newDecomposition(b, nil), nil
+	}
+
+	// exponent 1
+	one := newDecomposition(b, terms{monome{coeff: bigOne}})
+
+	builder := opts.builder(b)
+	for _, t := range s {
+		switch {
+		case t.base == nil && t.number.Cmp(b) == 0:
+			// bare base
+			builder.AddBig(bigOne, one)
+
+		case t.base == nil:
+			// constant
+			builder.AddBig(t.number, Decomposition{})
+
+		case t.base.Cmp(b) != 0:
+			return Decomposition{}, fmt.Errorf("%w: base %v in a base-%v decomposition", ErrBaseMismatch, t.base, b)
+
+		case t.exponent == nil:
+			// coeff * base
+			builder.AddBig(t.coeff, one)
+
+		default:
+			exp, err := t.exponent.decomposition(b, opts)
+			if err != nil {
+				return Decomposition{}, err
+			}
+			builder.AddBig(t.coeff, exp)
+		}
+	}
+	return builder.Build()
+}
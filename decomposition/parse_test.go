@@ -0,0 +1,170 @@
+package decomposition
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func ExampleParse() {
+	d, _ := Parse("2 ^ (2 + 1) + 2")
+	fmt.Println(d.Base(), d.Eval())
+
+	// Output:
+	// 2 10
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for i := 0; i < 100; i++ {
+		b := 2 + i%5
+		d := Rand(b, 3, rng)
+		for _, s := range []string{d.String(), d.LaTeX(), d.BreakableLaTeX()} {
+			parsed, err := ParseBase(b, s)
+			if err != nil {
+				t.Errorf("cannot parse %q: %v", s, err)
+				continue
+			}
+			if parsed.Base().Int64() != int64(b) || parsed.String() != d.String() {
+				t.Errorf("%q parsed as %q in base %v", s, parsed, parsed.Base())
+			}
+		}
+	}
+}
+
+func TestParseInferBase(t *testing.T) {
+	for _, g := range []struct {
+		s    string
+		base int
+		n    int64
+	}{
+		{"0", 2, 0},
+		{"1", 2, 1},
+		{"2", 2, 2},
+		{"3", 3, 3},
+		{"3 + 1", 3, 4},
+		{"2 * 3 ^ (2) + 1", 3, 19},
+		{`2 \times 3 ^ {2} + 1`, 3, 19},
+		{"4 ^ (4 ^ (4)) + 2", 4, 0}, // value not checked
+	} {
+		d, err := Parse(g.s)
+		if err != nil {
+			t.Errorf("cannot parse %q: %v", g.s, err)
+			continue
+		}
+		if d.Base().Int64() != int64(g.base) {
+			t.Errorf("%q: expected base %v, got %v", g.s, g.base, d.Base())
+		}
+		if g.n != 0 && d.Eval().Int64() != g.n {
+			t.Errorf("%q: expected value %v, got %v", g.s, g.n, d.Eval())
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"2 +",
+		"2 ^ (2",
+		"2 ^ (2 + 1}",
+		"3 ^ (2 ^ (2))",     // several bases
+		"3 ^ (2) + 3 ^ (2)", // same exponent twice
+		"2 * 2",             // coefficient too large
+		"x",
+	} {
+		if d, err := Parse(s); err == nil {
+			t.Errorf("invalid %q parsed as %q", s, d)
+		}
+	}
+	if d, err := ParseBase(1, "1"); err == nil {
+		t.Errorf("base 1 accepted: %q", d)
+	}
+	if d, err := ParseBase(3, "2 ^ (2)"); err == nil {
+		t.Errorf("base-2 decomposition parsed in base 3: %q", d)
+	}
+	b, _ := new(big.Int).SetString("100000000000000000000", 10)
+	if d, err := ParseBaseBig(b, "2 ^ (2)"); err == nil {
+		t.Errorf("base-2 decomposition parsed in base %v: %q", b, d)
+	}
+}
+
+func TestParseTooDeep(t *testing.T) {
+	// deeply nested input must fail before overflowing the stack
+	n := 3000000
+	for _, s := range []string{
+		strings.Repeat("2 ^ (", n) + "2" + strings.Repeat(")", n),
+		strings.Repeat("2 ^ ", n) + "2",
+	} {
+		if _, err := Parse(s); !errors.Is(err, ErrTooDeep) {
+			t.Errorf("expecting ErrTooDeep, got %v", err)
+		}
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	// 2 * 3 + 2 * 3 + 0 + 5 = 17 = 3 ^ (2) + 2 * 3 + 2
+	expected, _ := New(3, 17)
+	decoders := map[string]func(ParseOptions) (Decomposition, error){
+		"ParseWith": func(o ParseOptions) (Decomposition, error) {
+			return ParseWith("2 * 3 + 2 * 3 + 0 + 5", o)
+		},
+		"UnmarshalTextWith": func(o ParseOptions) (Decomposition, error) {
+			return UnmarshalTextWith([]byte("3:2 * 3 + 2 * 3 + 0 + 5"), o)
+		},
+		"UnmarshalJSONWith": func(o ParseOptions) (Decomposition, error) {
+			return UnmarshalJSONWith([]byte(`{"version":1,"base":3,"monomes":[
+				{"coeff":2,"exponent":[{"coeff":1}]},
+				{"coeff":2,"exponent":[{"coeff":1}]},
+				{"coeff":0},
+				{"coeff":5}
+			]}`), o)
+		},
+		"UnmarshalXMLWith": func(o ParseOptions) (Decomposition, error) {
+			dec := xml.NewDecoder(strings.NewReader(`<Decomposition version="1" base="3">
+				<monome coeff="2"><exponent><monome coeff="1"></monome></exponent></monome>
+				<monome coeff="2"><exponent><monome coeff="1"></monome></exponent></monome>
+				<monome coeff="0"></monome>
+				<monome coeff="5"></monome>
+			</Decomposition>`))
+			start, _ := dec.Token()
+			return UnmarshalXMLWith(dec, start.(xml.StartElement), o)
+		},
+		"UnmarshalBinaryWith": func(o ParseOptions) (Decomposition, error) {
+			return UnmarshalBinaryWith([]byte{
+				1,    // version
+				1, 3, // base
+				4,                // monomes
+				1, 2, 1, 1, 1, 0, // 2 * 3
+				1, 2, 1, 1, 1, 0, // 2 * 3
+				0, 0, // 0
+				1, 5, 0, // 5
+			}, o)
+		},
+	}
+	for name, decode := range decoders {
+		// strict by default
+		if d, err := decode(ParseOptions{Base: big.NewInt(3)}); err == nil {
+			t.Errorf("%v: non normalized decomposition accepted: %q", name, d)
+		}
+
+		d, err := decode(ParseOptions{Base: big.NewInt(3), Lenient: true})
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if !d.Equal(expected) {
+			t.Errorf("%v: got %q, expecting %q", name, d, expected)
+		}
+
+		// the normalized decomposition is read back strictly
+		text, _ := d.MarshalText()
+		var back Decomposition
+		if err := back.UnmarshalText(text); err != nil || !back.Equal(d) {
+			t.Errorf("%v: %q read back as %q: %v", name, text, back, err)
+		}
+	}
+}
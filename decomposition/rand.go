@@ -0,0 +1,64 @@
+package decomposition
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+// maxRandMonomes is the maximum number of monomes
+// at each level of a random decomposition.
+const maxRandMonomes = 4
+
+// Rand returns a random valid hereditary base-b decomposition
+// whose exponents are nested at most maxDepth times.
+// It is deterministic for a given rng state so a seeded rng
+// reproduces the same decompositions.
+// b must be at least 2 and maxDepth must be non negative.
+func Rand(b, maxDepth int, rng *rand.Rand) Decomposition {
+	if b < 2 {
+		panic("decomposition: base must be at least 2")
+	}
+	if maxDepth < 0 {
+		panic("decomposition: maxDepth must be non negative")
+	}
+	return newDecomposition(big.NewInt(int64(b)), randMonomes(b, maxDepth, rng))
+}
+
+// randMonomes returns random monomes sorted from
+// the least significant to the most significant one.
+func randMonomes(b, depth int, rng *rand.Rand) terms {
+	// no more nesting: the decomposition is a constant
+	if depth == 0 {
+		coeff := rng.Intn(b)
+		if coeff == 0 {
+			return nil
+		}
+		return terms{
+			monome{
+				coeff: big.NewInt(int64(coeff)),
+			},
+		}
+	}
+
+	// draw random exponents
+	n := rng.Intn(maxRandMonomes + 1)
+	exponents := make([]terms, n)
+	for i := range exponents {
+		exponents[i] = randMonomes(b, depth-1, rng)
+	}
+
+	// sort them and remove duplicates
+	sort.Slice(exponents, func(i, j int) bool { return exponents[i].cmp(exponents[j]) < 0 })
+	var monomes terms
+	for i, exp := range exponents {
+		if i > 0 && exp.cmp(exponents[i-1]) == 0 {
+			continue
+		}
+		monomes = append(monomes, monome{
+			coeff:    big.NewInt(int64(1 + rng.Intn(b-1))),
+			exponent: exp,
+		})
+	}
+	return monomes
+}
@@ -0,0 +1,41 @@
+package decomposition
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// valid returns true if the terms are a cleaned
+// hereditary base-b decomposition.
+func valid(t terms, b int) bool {
+	for i, m := range t {
+		if m.coeff.Sign() < 1 || m.coeff.Cmp(big.NewInt(int64(b))) >= 0 || !valid(m.exponent, b) {
+			return false
+		}
+		if i > 0 && t[i-1].exponent.cmp(m.exponent) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRandValid(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for b := 2; b < 6; b++ {
+		for i := 0; i < 100; i++ {
+			d := Rand(b, 3, rng)
+			if d.Base().Int64() != int64(b) || !valid(d.monomes, b) {
+				t.Errorf("invalid random base-%v decomposition %q", b, d)
+			}
+		}
+	}
+}
+
+func TestRandDeterministic(t *testing.T) {
+	d1 := Rand(3, 3, rand.New(rand.NewSource(42)))
+	d2 := Rand(3, 3, rand.New(rand.NewSource(42)))
+	if d1.String() != d2.String() {
+		t.Errorf("same seed gave %q and %q", d1, d2)
+	}
+}
@@ -0,0 +1,99 @@
+package decomposition
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout of SVG drawings.
+// Text is monospace so that the width of a run can be estimated from its length
+// without any font metrics.
+const (
+	svgFontSize      = 20.0 // of top-level monomes
+	svgExponentScale = 0.7  // ratio of the font size of an exponent to the one of its base
+	svgExponentRaise = 0.5  // raise of the baseline of an exponent, in units of the font size of its base
+	svgCharWidth     = 0.6  // width of a character, in units of the font size
+	svgAscent        = 0.8  // height above the baseline, in units of the font size
+	svgDescent       = 0.25 // depth below the baseline, in units of the font size
+	svgMargin        = 2.0
+)
+
+// svgRun is a piece of text at a given position and size.
+type svgRun struct {
+	x, y, size float64
+	text       string
+}
+
+// svgLayout places runs from left to right and keeps track of the bounding box.
+type svgLayout struct {
+	runs        []svgRun
+	x           float64 // where the next run starts
+	top, bottom float64
+}
+
+// write appends text with baseline y and font size.
+func (l *svgLayout) write(text string, y, size float64) {
+	l.runs = append(l.runs, svgRun{l.x, y, size, text})
+	l.x += float64(utf8.RuneCountInString(text)) * svgCharWidth * size
+	l.top = math.Min(l.top, y-svgAscent*size)
+	l.bottom = math.Max(l.bottom, y+svgDescent*size)
+}
+
+// terms lays out base-b terms, most significant monomes first.
+func (l *svgLayout) terms(b string, t terms, y, size float64) {
+	if t.isZero() {
+		l.write("0", y, size)
+		return
+	}
+	for i := len(t) - 1; i >= 0; i-- {
+		l.monome(b, t[i], y, size)
+		if i > 0 {
+			l.write("+", y, size)
+		}
+	}
+}
+
+// monome lays out a base-b monome with its exponent raised and scaled down.
+func (l *svgLayout) monome(b string, m monome, y, size float64) {
+	// constant
+	if m.exponent.isZero() {
+		l.write(m.coeff.String(), y, size)
+		return
+	}
+
+	// coefficient (or not)
+	if m.coeff.Cmp(bigOne) != 0 {
+		l.write(m.coeff.String()+"×", y, size)
+	}
+
+	// base ^ exponent
+	l.write(b, y, size)
+	if !m.exponent.isOne() {
+		l.terms(b, m.exponent, y-svgExponentRaise*size, svgExponentScale*size)
+	}
+}
+
+// SVG returns a standalone SVG document drawing the decomposition
+// with raised and scaled down exponents, e.g. for publication-quality figures.
+func (d Decomposition) SVG() string {
+	l := svgLayout{x: svgMargin}
+	l.terms(d.base.String(), d.monomes, 0, svgFontSize)
+
+	// view box around all runs
+	x, y := 0.0, l.top-svgMargin
+	w, h := l.x+svgMargin, l.bottom-l.top+2*svgMargin
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%.2f %.2f %.2f %.2f" width="%.2f" height="%.2f">`, x, y, w, h, w, h)
+	sb.WriteString("\n")
+	sb.WriteString(`<g font-family="monospace">`)
+	sb.WriteString("\n")
+	for _, r := range l.runs {
+		fmt.Fprintf(&sb, `<text x="%.2f" y="%.2f" font-size="%.2f">%v</text>`, r.x, r.y, r.size, r.text)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</g>\n</svg>")
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package decomposition
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func ExampleDecomposition_SVG() {
+	d, _ := New(2, 10)
+	fmt.Println(d.SVG())
+
+	// Output:
+	// <svg xmlns="http://www.w3.org/2000/svg" viewBox="0.00 -23.20 65.20 30.20" width="65.20" height="30.20">
+	// <g font-family="monospace">
+	// <text x="2.00" y="0.00" font-size="20.00">2</text>
+	// <text x="14.00" y="-10.00" font-size="14.00">2</text>
+	// <text x="22.40" y="-10.00" font-size="14.00">+</text>
+	// <text x="30.80" y="-10.00" font-size="14.00">1</text>
+	// <text x="39.20" y="0.00" font-size="20.00">+</text>
+	// <text x="51.20" y="0.00" font-size="20.00">2</text>
+	// </g>
+	// </svg>
+}
+
+func TestSVGWellFormed(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 100, 1000} {
+		d, _ := New(3, n)
+		dec := xml.NewDecoder(strings.NewReader(d.SVG()))
+		texts := 0
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				if err != io.EOF {
+					t.Errorf("invalid SVG for %v: %v", n, err)
+				}
+				break
+			}
+			if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "text" {
+				texts++
+			}
+		}
+		if texts == 0 {
+			t.Errorf("no text in SVG for %v", n)
+		}
+	}
+}
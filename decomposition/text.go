@@ -0,0 +1,57 @@
+package decomposition
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+// The text is the base followed by a colon and the decomposition
+// as printed by String, e.g. "2:2 ^ (2 + 1) + 2",
+// so that it can be read back without inferring the base.
+// The default value of Decomposition, which has no base, is an empty text.
+func (d Decomposition) MarshalText() ([]byte, error) {
+	if d.base == nil {
+		return []byte{}, nil
+	}
+	return []byte(d.base.String() + ":" + d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It reads the text written by MarshalText,
+// which makes decompositions usable with flag.TextVar for instance.
+// The decoded decomposition is validated like the ones built with Builder
+// and must not exceed DefaultLimits.
+func (d *Decomposition) UnmarshalText(text []byte) error {
+	decoded, err := UnmarshalTextWith(text, ParseOptions{})
+	if err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// UnmarshalTextWith is like Decomposition.UnmarshalText
+// but the decomposition is read according to the options.
+// The base of the options is ignored since the text holds the base.
+func UnmarshalTextWith(text []byte, opts ParseOptions) (Decomposition, error) {
+	// the default value of Decomposition has no base
+	if len(text) == 0 {
+		return Decomposition{}, nil
+	}
+
+	strBase, expr, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return Decomposition{}, fmt.Errorf("missing base in %q", text)
+	}
+	strBase = strings.TrimSpace(strBase)
+	if err := opts.limits().checkDigits(len(strBase)); err != nil {
+		return Decomposition{}, err
+	}
+	b, ok := new(big.Int).SetString(strBase, 10)
+	if !ok {
+		return Decomposition{}, fmt.Errorf("invalid base %q", strBase)
+	}
+	return parse(expr, fixedBase(b), opts)
+}
@@ -0,0 +1,68 @@
+package decomposition
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func ExampleDecomposition_MarshalText() {
+	d, _ := New(3, 2)
+	text, _ := d.MarshalText()
+	fmt.Println(string(text))
+
+	// Output:
+	// 3:2
+}
+
+func ExampleDecomposition_UnmarshalText() {
+	var d Decomposition
+	flags := flag.NewFlagSet("example", flag.ExitOnError)
+	flags.TextVar(&d, "start", Decomposition{}, "first decomposition of the sequence")
+	flags.Parse([]string{"-start", "3:3 ^ (2) + 1"})
+	fmt.Println(d.Eval())
+
+	// Output:
+	// 10
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		d := Rand(2+i%5, 3, rng)
+		text, err := d.MarshalText()
+		if err != nil {
+			t.Fatalf("cannot marshal %q: %v", d, err)
+		}
+		var decoded Decomposition
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("cannot unmarshal %s: %v", text, err)
+		}
+		if !decoded.Equal(d) {
+			t.Errorf("%q became %q", d, decoded)
+		}
+	}
+
+	// default value
+	var decoded Decomposition
+	text, _ := Decomposition{}.MarshalText()
+	if err := decoded.UnmarshalText(text); err != nil || !decoded.Equal(Decomposition{}) {
+		t.Errorf("default value became %q (base %v): %v", decoded, decoded.Base(), err)
+	}
+}
+
+func TestUnmarshalTextInvalid(t *testing.T) {
+	for _, s := range []string{
+		"2 ^ (2)",   // missing base
+		"x:2 ^ (2)", // invalid base
+		"1:1",       // base too small
+		"3:2 ^ (2)", // another base
+		"2:2 +",     // invalid expression
+	} {
+		var d Decomposition
+		if err := d.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("invalid %q decoded as %q", s, d)
+		}
+	}
+}
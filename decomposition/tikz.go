@@ -0,0 +1,90 @@
+package decomposition
+
+import (
+	"strings"
+)
+
+// TikZ returns the decomposition drawn as an expression tree
+// with the forest LaTeX package, one node per line.
+// Sums, products and powers are inner nodes whose children are their operands:
+// coefficients and the base are leaves and exponents are subtrees.
+// For instance, the base-3 decomposition of 2 * 3 ^ (3) + 1 is drawn as
+//
+//	% requires \usepackage{forest}
+//	\begin{forest}
+//	[$+$
+//	  [$\times$
+//	    [2]
+//	    [$\wedge$
+//	      [3]
+//	      [3]
+//	    ]
+//	  ]
+//	  [1]
+//	]
+//	\end{forest}
+func (d Decomposition) TikZ() string {
+	var sb strings.Builder
+	sb.WriteString("% requires \\usepackage{forest}\n")
+	sb.WriteString("\\begin{forest}\n")
+	writeForest(&sb, d.base.String(), d.monomes, 0)
+	sb.WriteString(`\end{forest}`)
+	return sb.String()
+}
+
+// writeForest writes the tree of base-b terms at the given depth,
+// with most significant monomes first.
+func writeForest(sb *strings.Builder, b string, t terms, depth int) {
+	switch len(t) {
+	case 0:
+		writeForestNode(sb, "[0]", depth)
+	case 1:
+		writeForestMonome(sb, b, t[0], depth)
+	default:
+		writeForestNode(sb, "[$+$", depth)
+		for i := len(t) - 1; i >= 0; i-- {
+			writeForestMonome(sb, b, t[i], depth+1)
+		}
+		writeForestNode(sb, "]", depth)
+	}
+}
+
+// writeForestMonome writes the tree of a base-b monome at the given depth.
+func writeForestMonome(sb *strings.Builder, b string, m monome, depth int) {
+	// constant
+	if m.exponent.isZero() {
+		writeForestNode(sb, "["+m.coeff.String()+"]", depth)
+		return
+	}
+
+	// coefficient (or not)
+	times := m.coeff.Cmp(bigOne) != 0
+	if times {
+		writeForestNode(sb, `[$\times$`, depth)
+		depth++
+		writeForestNode(sb, "["+m.coeff.String()+"]", depth)
+	}
+
+	// base ^ exponent
+	if m.exponent.isOne() {
+		writeForestNode(sb, "["+b+"]", depth)
+	} else {
+		writeForestNode(sb, `[$\wedge$`, depth)
+		writeForestNode(sb, "["+b+"]", depth+1)
+		writeForest(sb, b, m.exponent, depth+1)
+		writeForestNode(sb, "]", depth)
+	}
+
+	if times {
+		writeForestNode(sb, "]", depth-1)
+	}
+}
+
+// writeForestNode writes a line of the tree indented by depth.
+func writeForestNode(sb *strings.Builder, s string, depth int) {
+	for i := 0; i < depth; i++ {
+		sb.WriteString("  ")
+	}
+	sb.WriteString(s)
+	sb.WriteString("\n")
+}
@@ -0,0 +1,36 @@
+package decomposition
+
+import (
+	"fmt"
+)
+
+func ExampleDecomposition_TikZ() {
+	d, _ := New(3, 2*27+1)
+	fmt.Println(d.TikZ())
+
+	// Output:
+	// % requires \usepackage{forest}
+	// \begin{forest}
+	// [$+$
+	//   [$\times$
+	//     [2]
+	//     [$\wedge$
+	//       [3]
+	//       [3]
+	//     ]
+	//   ]
+	//   [1]
+	// ]
+	// \end{forest}
+}
+
+func ExampleDecomposition_TikZ_zero() {
+	var d Decomposition
+	fmt.Println(d.TikZ())
+
+	// Output:
+	// % requires \usepackage{forest}
+	// \begin{forest}
+	// [0]
+	// \end{forest}
+}
@@ -0,0 +1,128 @@
+package decomposition
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math/big"
+)
+
+// xmlVersion is the version of the XML encoding of decompositions.
+// It is incremented whenever the encoding changes,
+// older versions remaining decodable.
+const xmlVersion = 1
+
+// xmlDecomposition is the XML encoding of a Decomposition.
+type xmlDecomposition struct {
+	Version int         `xml:"version,attr"`
+	Base    *big.Int    `xml:"base,attr"`
+	Monomes []xmlMonome `xml:"monome"`
+}
+
+// xmlMonome is the XML encoding of a monome.
+// Its base is the one of the enclosing decomposition.
+// A zero exponent is omitted.
+type xmlMonome struct {
+	Coeff    *big.Int     `xml:"coeff,attr"`
+	Exponent *xmlExponent `xml:"exponent,omitempty"`
+}
+
+// xmlExponent is the XML encoding of the exponent of a monome.
+type xmlExponent struct {
+	Monomes []xmlMonome `xml:"monome"`
+}
+
+// MarshalXML implements xml.Marshaler.
+// The decomposition is encoded as a tree of monome elements
+// from the most significant to the least significant one,
+// each of them with a coeff attribute and an exponent element, e.g.
+//
+//	<Decomposition version="1" base="2">
+//	  <monome coeff="1">
+//	    <exponent>
+//	      <monome coeff="1">
+//	        <exponent><monome coeff="1"></monome></exponent>
+//	      </monome>
+//	    </exponent>
+//	  </monome>
+//	  <monome coeff="1"></monome>
+//	</Decomposition>
+//
+// for 2 ^ (2) + 1 (indented here for readability).
+// A zero exponent is omitted.
+// Unlike a presentation format like MathML, this tree mirrors the structure of the decomposition.
+func (d Decomposition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(xmlDecomposition{
+		Version: xmlVersion,
+		Base:    d.Base(), // 0 for the default value
+		Monomes: toXML(d.monomes),
+	}, start)
+}
+
+// toXML returns the XML encoding of the terms, most significant monome first.
+func toXML(t terms) []xmlMonome {
+	if t.isZero() {
+		return nil
+	}
+	monomes := make([]xmlMonome, len(t))
+	for i, m := range t {
+		monomes[len(t)-1-i].Coeff = m.coeff
+		if !m.exponent.isZero() {
+			monomes[len(t)-1-i].Exponent = &xmlExponent{toXML(m.exponent)}
+		}
+	}
+	return monomes
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The decoded decomposition is validated like the ones built with Builder
+// and must not exceed DefaultLimits.
+func (d *Decomposition) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	decoded, err := UnmarshalXMLWith(dec, start, ParseOptions{})
+	if err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// UnmarshalXMLWith is like Decomposition.UnmarshalXML
+// but the decomposition is read according to the options.
+// The base of the options is ignored since the encoding holds the base.
+func UnmarshalXMLWith(dec *xml.Decoder, start xml.StartElement, opts ParseOptions) (Decomposition, error) {
+	var x xmlDecomposition
+	if err := dec.DecodeElement(&x, &start); err != nil {
+		return Decomposition{}, err
+	}
+	if x.Version != xmlVersion {
+		return Decomposition{}, fmt.Errorf("unsupported XML encoding version %v", x.Version)
+	}
+
+	// the default value of Decomposition has no base
+	if x.Base != nil && x.Base.Sign() == 0 && len(x.Monomes) == 0 {
+		return Decomposition{}, nil
+	}
+
+	return fromXML(x.Base, x.Monomes, opts)
+}
+
+// fromXML builds the base-b decomposition encoded by the monomes, according to the options.
+func fromXML(b *big.Int, monomes []xmlMonome, opts ParseOptions) (Decomposition, error) {
+	if b == nil {
+		return Decomposition{}, fmt.Errorf("missing base")
+	}
+	builder := opts.builder(b)
+	for _, m := range monomes {
+		var exp Decomposition
+		if m.Exponent != nil {
+			var err error
+			if exp, err = fromXML(b, m.Exponent.Monomes, opts); err != nil {
+				return Decomposition{}, err
+			}
+		}
+		if m.Coeff == nil {
+			return Decomposition{}, fmt.Errorf("missing coefficient")
+		}
+		builder.AddBig(m.Coeff, exp)
+	}
+	return builder.Build()
+}
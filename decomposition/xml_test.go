@@ -0,0 +1,57 @@
+package decomposition
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func ExampleDecomposition_MarshalXML() {
+	d, _ := New(2, 5)
+	b, _ := xml.Marshal(d)
+	fmt.Println(string(b))
+
+	// Output:
+	// <Decomposition version="1" base="2"><monome coeff="1"><exponent><monome coeff="1"><exponent><monome coeff="1"></monome></exponent></monome></exponent></monome><monome coeff="1"></monome></Decomposition>
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		d := Rand(2+i%5, 3, rng)
+		b, err := xml.Marshal(d)
+		if err != nil {
+			t.Fatalf("cannot marshal %q: %v", d, err)
+		}
+		var decoded Decomposition
+		if err := xml.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("cannot unmarshal %s: %v", b, err)
+		}
+		if decoded.Base().Cmp(d.Base()) != 0 || decoded.String() != d.String() {
+			t.Errorf("%q became %q", d, decoded)
+		}
+	}
+
+	// default value
+	var decoded Decomposition
+	b, _ := xml.Marshal(Decomposition{})
+	if err := xml.Unmarshal(b, &decoded); err != nil || decoded.Base().Sign() != 0 || !decoded.IsZero() {
+		t.Errorf("default value became %q (base %v): %v", decoded, decoded.Base(), err)
+	}
+}
+
+func TestUnmarshalXMLInvalid(t *testing.T) {
+	for _, s := range []string{
+		`<Decomposition version="2" base="2"></Decomposition>`,                                                       // unknown version
+		`<Decomposition version="1" base="1"><monome coeff="1"></monome></Decomposition>`,                            // base too small
+		`<Decomposition version="1" base="2"><monome coeff="2"></monome></Decomposition>`,                            // coefficient too large
+		`<Decomposition version="1" base="3"><monome coeff="1"></monome><monome coeff="2"></monome></Decomposition>`, // same exponent
+		`<Decomposition version="1" base="2"><monome coeff="x"></monome></Decomposition>`,                            // not a number
+	} {
+		var d Decomposition
+		if err := xml.Unmarshal([]byte(s), &d); err == nil {
+			t.Errorf("invalid %s decoded as %q", s, d)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// alignLineWidth is the maximum length of the LaTeX code of a line of an align* environment
+// before the next top-level monome is moved to a new line.
+const alignLineWidth = 80
+
+// writeDocumentBegin writes the preamble of a standalone LaTeX document
+// and begins its body.
+func writeDocumentBegin(w io.Writer) {
+	fmt.Fprintln(w, `\documentclass{article}`)
+	fmt.Fprintln(w, `\usepackage[margin=2cm]{geometry}`)
+	fmt.Fprintln(w, `\usepackage{amsmath}`)
+	fmt.Fprintln(w, `\usepackage{longtable}`)
+	fmt.Fprintln(w, `\allowdisplaybreaks`)
+	fmt.Fprintln(w, `\begin{document}`)
+}
+
+// writeDocumentEnd ends a standalone LaTeX document.
+func writeDocumentEnd(w io.Writer) {
+	fmt.Fprintln(w, `\end{document}`)
+}
+
+// writeAlignBegin starts an align* environment of iterations.
+func writeAlignBegin(w io.Writer) {
+	fmt.Fprintln(w, `\begin{align*}`)
+}
+
+// writeAlignRow writes the i-th iteration as rows of an align* environment,
+// tagged with its base. Long decompositions are broken between top-level monomes
+// so that each line has at most alignLineWidth characters of LaTeX code, if possible.
+// Rows are separated by a line break, which must not follow the last row,
+// so first must be true for the first row only.
+func writeAlignRow(w io.Writer, i *big.Int, d decomposition.Decomposition, first bool) {
+	if !first {
+		fmt.Fprint(w, `\\ `)
+	}
+	lines := alignLines(d)
+	fmt.Fprintf(w, "G_{%v} &= %v \\tag*{$b = %v$}", i, lines[0], d.Base())
+	for _, line := range lines[1:] {
+		fmt.Fprintf(w, " \\\\\n&\\quad + %v", line)
+	}
+	fmt.Fprintln(w)
+}
+
+// alignLines returns the LaTeX code of the decomposition split into lines
+// between top-level monomes.
+func alignLines(d decomposition.Decomposition) []string {
+	if d.IsZero() {
+		return []string{"0"}
+	}
+
+	var (
+		lines []string
+		line  strings.Builder
+	)
+	for m := range d.Terms() {
+		monome := decomposition.NewBuilderBig(m.Base()).AddBig(m.Coeff(), m.Exponent()).MustBuild().LaTeX()
+		if line.Len() > 0 && line.Len()+len(monome) > alignLineWidth {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteString(" + ")
+		}
+		line.WriteString(monome)
+	}
+	return append(lines, line.String())
+}
+
+// writeAlignEnd ends an align* environment.
+func writeAlignEnd(w io.Writer) {
+	fmt.Fprintln(w, `\end{align*}`)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// eqCommand reports whether two expressions denote the same number.
+// Each expression is either a hereditary decomposition, e.g. "2 ^ (2 + 1) + 2",
+// or a value with its base, e.g. "10 in base 2".
+func eqCommand(args []string) error {
+	flags := flag.NewFlagSet("eq", flag.ExitOnError)
+	flags.Parse(args)
+	if flags.NArg() != 2 {
+		return fmt.Errorf("expecting two expressions")
+	}
+
+	d1, err := parseExpression(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid first expression: %v", err)
+	}
+	d2, err := parseExpression(flags.Arg(1))
+	if err != nil {
+		return fmt.Errorf("invalid second expression: %v", err)
+	}
+
+	fmt.Fprintln(os.Stdout, equal(d1, d2))
+	return nil
+}
+
+// parseExpression parses a hereditary decomposition
+// or a value followed by its base, e.g. "10 in base 2".
+func parseExpression(s string) (decomposition.Decomposition, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 4 && fields[1] == "in" && fields[2] == "base" {
+		n, ok := new(big.Int).SetString(fields[0], 10)
+		if !ok {
+			return decomposition.Decomposition{}, fmt.Errorf("invalid value %q", fields[0])
+		}
+		b, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return decomposition.Decomposition{}, err
+		}
+		return decomposition.NewBig(b, n)
+	}
+	return parseDecomposition(s, 0)
+}
+
+// equal returns true if both decompositions denote the same number.
+// Decompositions in the same base are compared structurally,
+// which does not require to evaluate them.
+func equal(d1, d2 decomposition.Decomposition) bool {
+	if d1.Base().Cmp(d2.Base()) == 0 {
+		return d1.Equal(d2)
+	}
+	return d1.Eval().Cmp(d2.Eval()) == 0
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// evalCommand prints the exact value of a hereditary decomposition.
+func evalCommand(args []string) error {
+	flags := flag.NewFlagSet("eval", flag.ExitOnError)
+	base := flags.Int("base", 0, "base of the decomposition, inferred from the expression if zero")
+	timeout := flags.Duration("timeout", 0, "if positive, evaluation is aborted after this duration")
+	ord := flags.Bool("ordinal", false, "also print the ordinal of the decomposition, the base replaced by ω")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expecting one expression")
+	}
+
+	// evaluation of deep decompositions may not terminate in any reasonable time
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	return evalExpression(ctx, os.Stdout, flags.Arg(0), *base, *ord)
+}
+
+// evalExpression writes the value of the expression in base b, or in an inferred base if b is zero,
+// followed by its ordinal if ord is true.
+func evalExpression(ctx context.Context, w io.Writer, expr string, b int, ord bool) error {
+	d, err := parseDecomposition(expr, b)
+	if err != nil {
+		return fmt.Errorf("invalid expression: %v", err)
+	}
+
+	value, err := d.EvalContext(ctx)
+	if err != nil {
+		return fmt.Errorf("evaluation aborted: %v", err)
+	}
+
+	fmt.Fprintln(w, value)
+	if ord {
+		fmt.Fprintln(w, d.ToOrdinal())
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	for _, g := range []struct {
+		expr     string
+		b        int
+		ord      bool
+		expected string
+	}{
+		{"3 ^ (3) + 2 * 3 + 1", 0, false, "34\n"},
+		{"3 ^ (3) + 2 * 3 + 1", 0, true, "34\nω ^ (ω) + ω * 2 + 1\n"},
+		{"2 ^ (2 + 1) + 2", 0, true, "10\nω ^ (ω + 1) + ω\n"},
+		{"4", 5, true, "4\n4\n"},
+	} {
+		var sb strings.Builder
+		if err := evalExpression(context.Background(), &sb, g.expr, g.b, g.ord); err != nil {
+			t.Errorf("%q: %v", g.expr, err)
+			continue
+		}
+		if sb.String() != g.expected {
+			t.Errorf("%q: got %q, expecting %q", g.expr, sb.String(), g.expected)
+		}
+	}
+}
+
+func TestEvalInvalid(t *testing.T) {
+	var sb strings.Builder
+	if err := evalExpression(context.Background(), &sb, "3 ^ (", 0, true); err == nil {
+		t.Errorf("invalid expression evaluated to %q", sb.String())
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// format is a representation of decompositions.
+type format struct {
+	// parse reads a decomposition in base b (or in an inferred base if b is zero).
+	// It is nil if the format cannot be read.
+	parse func(s string, b int) (decomposition.Decomposition, error)
+
+	// format writes a decomposition.
+	format func(d decomposition.Decomposition) string
+}
+
+// formats is the registry of representation formats, by name.
+var formats = map[string]format{
+	"string":           {parseDecomposition, decomposition.Decomposition.String},
+	"latex":            {parseDecomposition, decomposition.Decomposition.LaTeX},
+	"mathml":           {nil, decomposition.Decomposition.MathML},
+	"compact":          {parseCompact, decomposition.Decomposition.Compact},
+	"go":               {nil, decomposition.Decomposition.GoString},
+	"html":             {nil, decomposition.Decomposition.HTML},
+	"json":             {parseJSON, formatJSON},
+	"python":           {nil, decomposition.Decomposition.Python},
+	"sexpr":            {nil, decomposition.Decomposition.SExpr},
+	"svg":              {nil, decomposition.Decomposition.SVG},
+	"sympy":            {nil, decomposition.Decomposition.SymPy},
+	"text":             {parseText, formatText},
+	"tikz":             {nil, decomposition.Decomposition.TikZ},
+	"typst":            {nil, decomposition.Decomposition.Typst},
+	"unicode":          {nil, formatUnicode},
+	"value":            {parseValue, func(d decomposition.Decomposition) string { return d.Eval().String() }},
+	"wolfram":          {nil, decomposition.Decomposition.Wolfram},
+	"wolfram-fullform": {nil, decomposition.Decomposition.WolframFullForm},
+	"xml":              {parseXML, formatXML},
+}
+
+// formatNames returns the sorted names of the registered formats.
+func formatNames() string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// parseDecomposition parses a decomposition as printed by String or LaTeX
+// in base b, or in an inferred base if b is zero.
+func parseDecomposition(s string, b int) (decomposition.Decomposition, error) {
+	if b == 0 {
+		return decomposition.Parse(s)
+	}
+	return decomposition.ParseBase(b, s)
+}
+
+// parseCompact reads a decomposition in compact notation,
+// whose base is read from the expression if b is zero.
+func parseCompact(s string, b int) (decomposition.Decomposition, error) {
+	if b == 0 {
+		return decomposition.ParseCompact(s)
+	}
+	return decomposition.ParseBase(b, s)
+}
+
+// parseValue returns the base-b decomposition of a value.
+// The base cannot be inferred from a value so it must be given.
+func parseValue(s string, b int) (decomposition.Decomposition, error) {
+	if b == 0 {
+		return decomposition.Decomposition{}, fmt.Errorf("base is required to decompose a value")
+	}
+	n, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return decomposition.Decomposition{}, fmt.Errorf("invalid value %q", s)
+	}
+	return decomposition.NewBig(b, n)
+}
+
+// parseJSON reads the JSON encoding of a decomposition.
+// The base is part of the encoding so b is ignored.
+func parseJSON(s string, b int) (decomposition.Decomposition, error) {
+	var d decomposition.Decomposition
+	err := json.Unmarshal([]byte(s), &d)
+	return d, err
+}
+
+// formatJSON writes the JSON encoding of a decomposition on a single line.
+func formatJSON(d decomposition.Decomposition) string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		// a valid decomposition can always be encoded
+		panic(err)
+	}
+	return string(b)
+}
+
+// parseText reads the text encoding of a decomposition, "b:expr".
+// The base is part of the encoding so b is ignored.
+func parseText(s string, b int) (decomposition.Decomposition, error) {
+	var d decomposition.Decomposition
+	err := d.UnmarshalText([]byte(s))
+	return d, err
+}
+
+// formatText writes the text encoding of a decomposition.
+func formatText(d decomposition.Decomposition) string {
+	text, _ := d.MarshalText()
+	return string(text)
+}
+
+// formatUnicode writes a decomposition with superscript exponents, when possible.
+func formatUnicode(d decomposition.Decomposition) string {
+	return d.FormatWith(decomposition.FormatOptions{Superscript: true})
+}
+
+// parseXML reads the XML encoding of a decomposition.
+// The base is part of the encoding so b is ignored.
+func parseXML(s string, b int) (decomposition.Decomposition, error) {
+	var d decomposition.Decomposition
+	err := xml.Unmarshal([]byte(s), &d)
+	return d, err
+}
+
+// formatXML writes the XML encoding of a decomposition on a single line.
+func formatXML(d decomposition.Decomposition) string {
+	b, err := xml.Marshal(d)
+	if err != nil {
+		// a valid decomposition can always be encoded
+		panic(err)
+	}
+	return string(b)
+}
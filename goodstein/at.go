@@ -0,0 +1,104 @@
+package goodstein
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+var (
+	// ErrNotSupported is returned when the element of a sequence
+	// has no known closed form.
+	ErrNotSupported = errors.New("goodstein: no closed form")
+	// ErrTerminated is returned when the element of a sequence
+	// lies beyond its termination.
+	ErrTerminated = errors.New("goodstein: sequence terminated before")
+)
+
+// At returns the decomposition of the k-th step of the sequence
+// without iterating, with standard rules only.
+// It is supported while decompositions remain linear, i.e. c1 * b + c0,
+// which is the case for all steps of seeds up to 3:
+// the constant c0 decreases by one at each step until it is zero,
+// then one is borrowed from c1 and c0 becomes b - 1 again.
+// The closed form is applied from the current step if k is not lower,
+// from the seed otherwise.
+// It returns an error wrapping ErrNotSupported if there is no such closed form,
+// and one wrapping ErrTerminated if the sequence reaches zero before step k.
+// The sequence is left unchanged.
+func (s *Sequence) At(k *big.Int) (decomposition.Decomposition, error) {
+	if k.Sign() < 0 {
+		return decomposition.Decomposition{}, fmt.Errorf("negative step %v", k)
+	}
+	if s.bump != nil || s.subtract != nil {
+		return decomposition.Decomposition{}, fmt.Errorf("%w: custom rules", ErrNotSupported)
+	}
+
+	// start from the current step, or from the seed
+	step, d := new(big.Int).Set(s.step), s.d
+	if step.Cmp(k) > 0 {
+		step.SetInt64(0)
+		d, _ = decomposition.New(2, s.seed)
+	}
+
+	c1, c0, ok := linear(d)
+	if !ok {
+		return decomposition.Decomposition{}, fmt.Errorf("%w: step %v is not linear", ErrNotSupported, step)
+	}
+
+	// base of the standard rule
+	b := new(big.Int).Add(step, big.NewInt(2))
+	for {
+		// steps left
+		left := new(big.Int).Sub(k, step)
+		if left.Cmp(c0) <= 0 {
+			b.Add(b, left)
+			return linearDecomposition(b, c1, c0.Sub(c0, left)), nil
+		}
+		if c1.Sign() == 0 {
+			return decomposition.Decomposition{}, fmt.Errorf("%w: step %v", ErrTerminated, k)
+		}
+
+		// c0 reaches zero, then one is borrowed from c1 at the next step
+		jump := new(big.Int).Add(c0, big.NewInt(1))
+		step.Add(step, jump)
+		b.Add(b, jump)
+		c1.Sub(c1, big.NewInt(1))
+		c0.Sub(b, big.NewInt(1))
+	}
+}
+
+// linear returns c1 and c0 if d is c1 * b + c0.
+func linear(d decomposition.Decomposition) (c1, c0 *big.Int, ok bool) {
+	c1, c0 = new(big.Int), new(big.Int)
+	if d.Depth() > 2 {
+		return nil, nil, false
+	}
+	for m := range d.Terms() {
+		// exponents are constants, hence cheap to evaluate
+		switch e := m.Exponent().Eval(); e.Int64() {
+		case 0:
+			c0 = m.Coeff()
+		case 1:
+			c1 = m.Coeff()
+		default:
+			return nil, nil, false
+		}
+	}
+	return c1, c0, true
+}
+
+// linearDecomposition returns the base-b decomposition c1 * b + c0.
+func linearDecomposition(b, c1, c0 *big.Int) decomposition.Decomposition {
+	bd := decomposition.NewBuilderBig(b)
+	if c1.Sign() != 0 {
+		one := decomposition.NewBuilderBig(b).Add(1, decomposition.Decomposition{}).MustBuild()
+		bd.AddBig(c1, one)
+	}
+	if c0.Sign() != 0 {
+		bd.AddBig(c0, decomposition.Decomposition{})
+	}
+	return bd.MustBuild()
+}
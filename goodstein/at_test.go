@@ -0,0 +1,79 @@
+package goodstein
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func ExampleSequence_At() {
+	s, _ := NewSequence(3)
+	d, _ := s.At(big.NewInt(4))
+	fmt.Println(d)
+	// Output:
+	// 1
+}
+
+func TestAt(t *testing.T) {
+	// closed forms match iterations
+	for seed := 0; seed <= 3; seed++ {
+		s, _ := NewSequence(seed)
+		for i, d := range Steps(seed) {
+			got, err := s.At(i)
+			if err != nil {
+				t.Errorf("seed %v at %v: unexpected error: %v", seed, i, err)
+				continue
+			}
+			if !got.Equal(d) {
+				t.Errorf("seed %v at %v: got %q, expecting %q", seed, i, got, d)
+			}
+		}
+
+		// beyond termination
+		length, _, _ := Length(seed)
+		if _, err := s.At(length.Add(length, big.NewInt(1))); !errors.Is(err, ErrTerminated) {
+			t.Errorf("seed %v after termination: got %v, expecting %v", seed, err, ErrTerminated)
+		}
+	}
+}
+
+func TestAtFromCurrent(t *testing.T) {
+	// At does not depend on the current step
+	s, _ := NewSequence(3)
+	for s.Next() && s.Step().Int64() < 2 {
+	}
+	for _, g := range []struct {
+		k        int64
+		expected string
+	}{
+		{0, "2 + 1"},
+		{1, "3"},
+		{2, "3"},
+		{4, "1"},
+		{5, "0"},
+	} {
+		d, err := s.At(big.NewInt(g.k))
+		if err != nil {
+			t.Errorf("at %v: unexpected error: %v", g.k, err)
+			continue
+		}
+		if d.String() != g.expected {
+			t.Errorf("at %v: got %q, expecting %q", g.k, d, g.expected)
+		}
+	}
+	if s.Step().Int64() != 2 {
+		t.Errorf("sequence moved to step %v", s.Step())
+	}
+}
+
+func TestAtNotSupported(t *testing.T) {
+	s, _ := NewSequence(4)
+	if _, err := s.At(big.NewInt(1)); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, expecting %v", err, ErrNotSupported)
+	}
+	s, _ = NewSequence(3, Bump(MulBase(2)))
+	if _, err := s.At(big.NewInt(1)); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("custom rules: got %v, expecting %v", err, ErrNotSupported)
+	}
+}
@@ -0,0 +1,10 @@
+/*
+Package goodstein provides Goodstein sequences and their lengths.
+
+The Goodstein sequence of a seed n starts with the hereditary base-2 decomposition of n.
+Each step replaces the base b by b + 1 and removes one, until zero is reached.
+Although values grow extremely fast, every sequence terminates:
+replacing the base by omega maps each decomposition to an ordinal
+which strictly decreases at each step.
+*/
+package goodstein
@@ -0,0 +1,44 @@
+package goodstein
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/batiazinga/goodstein/decomposition"
+	"github.com/batiazinga/goodstein/ordinal"
+)
+
+// Length returns the number of steps of the Goodstein sequence of seed,
+// i.e. the step at which the sequence reaches zero.
+//
+// The length is H_a(3) - 3 where H is the Hardy hierarchy
+// and a is the ordinal of the hereditary base-2 decomposition of seed.
+// This expression is returned as symbolic, e.g. "H_(ω ^ (ω))(3) - 3" for 4.
+// The exact length is only returned for seeds up to 4,
+// the length of 4 being H_(ω ^ (ω))(3) - 3 = 3 * 2 ^ 402653211 - 3.
+// It is nil for larger seeds, which have lengths far too large to be computed.
+func Length(seed int) (exact *big.Int, symbolic string, err error) {
+	d, err := decomposition.New(2, seed)
+	if err != nil {
+		return nil, "", err
+	}
+	a := d.ToOrdinal()
+	symbolic = fmt.Sprintf("H_(%v)(3) - 3", a)
+
+	switch {
+	case seed <= 3:
+		h, err := ordinal.Hardy(a, 3)
+		if err != nil {
+			return nil, "", err
+		}
+		return h.Sub(h, big.NewInt(3)), symbolic, nil
+
+	case seed == 4:
+		// H_(ω ^ (ω))(3) - 3 = 3 * 2 ^ 402653211 - 3
+		exact = new(big.Int).Lsh(big.NewInt(3), 402653211)
+		return exact.Sub(exact, big.NewInt(3)), symbolic, nil
+
+	default:
+		return nil, symbolic, nil
+	}
+}
@@ -0,0 +1,80 @@
+package goodstein
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+func ExampleLength() {
+	exact, symbolic, _ := Length(3)
+	fmt.Println(exact, symbolic)
+	// Output:
+	// 5 H_(ω + 1)(3) - 3
+}
+
+func TestLength(t *testing.T) {
+	for _, g := range []struct {
+		seed     int
+		exact    int64 // -1 if not computed
+		symbolic string
+	}{
+		{0, 0, "H_(0)(3) - 3"},
+		{1, 1, "H_(1)(3) - 3"},
+		{2, 3, "H_(ω)(3) - 3"},
+		{3, 5, "H_(ω + 1)(3) - 3"},
+		{5, -1, "H_(ω ^ (ω) + 1)(3) - 3"},
+		{16, -1, "H_(ω ^ (ω ^ (ω)))(3) - 3"},
+	} {
+		exact, symbolic, err := Length(g.seed)
+		if err != nil {
+			t.Errorf("length of %v: unexpected error: %v", g.seed, err)
+			continue
+		}
+		if symbolic != g.symbolic {
+			t.Errorf("length of %v: got %q, expecting %q", g.seed, symbolic, g.symbolic)
+		}
+		switch {
+		case g.exact < 0 && exact != nil:
+			t.Errorf("length of %v: got %v, expecting none", g.seed, exact)
+		case g.exact >= 0 && (exact == nil || exact.Cmp(big.NewInt(g.exact)) != 0):
+			t.Errorf("length of %v: got %v, expecting %v", g.seed, exact, g.exact)
+		}
+	}
+}
+
+func TestLengthFour(t *testing.T) {
+	exact, _, err := Length(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3 * 2 ^ 402653211 - 3
+	expected := new(big.Int).Lsh(big.NewInt(3), 402653211)
+	expected.Sub(expected, big.NewInt(3))
+	if exact.Cmp(expected) != 0 {
+		t.Errorf("wrong length of 4: got %v bits, expecting 3 * 2 ^ 402653211 - 3", exact.BitLen())
+	}
+}
+
+func TestLengthIterated(t *testing.T) {
+	// exact lengths are the number of steps of the sequence
+	for seed := 0; seed <= 3; seed++ {
+		exact, _, _ := Length(seed)
+		d, _ := decomposition.New(2, seed)
+		steps := 0
+		for ; !d.IsZero(); steps++ {
+			d = d.IncrementBase().Decrement()
+		}
+		if exact.Cmp(big.NewInt(int64(steps))) != 0 {
+			t.Errorf("length of %v: got %v, expecting %v", seed, exact, steps)
+		}
+	}
+}
+
+func TestLengthNegative(t *testing.T) {
+	if _, _, err := Length(-1); err == nil {
+		t.Errorf("expecting an error for a negative seed")
+	}
+}
@@ -0,0 +1,53 @@
+package goodstein
+
+// BaseRule returns the base of the next step from the index of the current step
+// and its base. The returned base must be greater than the current one.
+// Many variants of Goodstein sequences only differ by this rule.
+type BaseRule func(step, base int) int
+
+// Bump sets the rule bumping the base at each step, instead of b -> b + 1.
+// If the rule returns a base which is not greater than the current one,
+// e.g. because of an overflow, the sequence stops with an error.
+// It also stops with an error once the step or the base does not fit in an int.
+func Bump(rule BaseRule) Option {
+	return func(c *config) { c.bump = rule }
+}
+
+// AddBase returns the rule b -> b + k.
+// k must be positive.
+func AddBase(k int) BaseRule {
+	if k < 1 {
+		panic("goodstein: base increment must be positive")
+	}
+	return func(_, b int) int { return b + k }
+}
+
+// MulBase returns the rule b -> k * b.
+// k must be at least 2.
+func MulBase(k int) BaseRule {
+	if k < 2 {
+		panic("goodstein: base factor must be at least 2")
+	}
+	return func(_, b int) int { return k * b }
+}
+
+// SquareBase is the rule b -> b * b.
+func SquareBase(_, b int) int {
+	return b * b
+}
+
+// Subtract makes each step subtract c instead of one, stopping at zero.
+// c must be positive.
+func Subtract(c int) Option {
+	if c < 1 {
+		panic("goodstein: subtracted constant must be positive")
+	}
+	return func(cfg *config) { cfg.subtract = func(int) int { return c } }
+}
+
+// SubtractFunc makes each step subtract f(step) instead of one, stopping at zero,
+// where step is the index of the current step.
+// If f returns a non positive number, the sequence stops with an error.
+func SubtractFunc(f func(step int) int) Option {
+	return func(c *config) { c.subtract = f }
+}
@@ -0,0 +1,126 @@
+package goodstein
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleBump() {
+	for i, d := range Steps(3, Bump(MulBase(2)), MaxSteps(3)) {
+		fmt.Println(i, d)
+	}
+	// Output:
+	// 0 2 + 1
+	// 1 4
+	// 2 7
+	// 3 6
+}
+
+func TestBump(t *testing.T) {
+	for _, g := range []struct {
+		rule  BaseRule
+		bases []int
+	}{
+		{AddBase(1), []int{2, 3, 4, 5}},
+		{AddBase(2), []int{2, 4, 6, 8}},
+		{MulBase(3), []int{2, 6, 18, 54}},
+		{SquareBase, []int{2, 4, 16, 256}},
+		{func(step, b int) int { return b + step + 1 }, []int{2, 3, 5, 8}},
+	} {
+		s, _ := NewSequence(4, Bump(g.rule), MaxSteps(len(g.bases)-1))
+		var bases []int
+		for s.Next() {
+			bases = append(bases, int(s.Base().Int64()))
+			if s.Current().Base().Cmp(s.Base()) != 0 {
+				t.Errorf("base of decomposition %v, expecting %v", s.Current().Base(), s.Base())
+			}
+		}
+		if fmt.Sprint(bases) != fmt.Sprint(g.bases) {
+			t.Errorf("got bases %v, expecting %v", bases, g.bases)
+		}
+		if s.Err() != nil {
+			t.Errorf("unexpected error: %v", s.Err())
+		}
+	}
+}
+
+func TestBumpValues(t *testing.T) {
+	// each step is the value in the new base minus one
+	s, _ := NewSequence(5, Bump(SquareBase), MaxSteps(3))
+	s.Next()
+	previous := s.Current()
+	for s.Next() {
+		bumped, _ := previous.WithBase(int(s.Base().Int64()))
+		if expected := bumped.Eval().Int64() - 1; s.Current().Eval().Int64() != expected {
+			t.Errorf("got %v at step %v, expecting %v", s.Current().Eval(), s.Step(), expected)
+		}
+		previous = s.Current()
+	}
+}
+
+func TestBumpError(t *testing.T) {
+	s, _ := NewSequence(4, Bump(func(_, b int) int { return b }))
+	s.Next()
+	if s.Next() {
+		t.Errorf("base rule not increasing the base is accepted")
+	}
+	if s.Err() == nil {
+		t.Errorf("expecting an error")
+	}
+	if s.Done() {
+		t.Errorf("sequence stopped by an error is done")
+	}
+}
+
+func ExampleSubtract() {
+	for i, d := range Steps(4, Subtract(2), MaxSteps(3)) {
+		fmt.Println(i, d)
+	}
+	// Output:
+	// 0 2 ^ (2)
+	// 1 2 * 3 ^ (2) + 2 * 3 + 1
+	// 2 2 * 4 ^ (2) + 4 + 3
+	// 3 2 * 5 ^ (2) + 5 + 1
+}
+
+func TestSubtract(t *testing.T) {
+	for _, g := range []struct {
+		seed int
+		opt  Option
+	}{
+		{5, Subtract(1)},
+		{5, Subtract(3)},
+		{7, Subtract(7)},
+		{6, SubtractFunc(func(step int) int { return step + 1 })},
+	} {
+		s, _ := NewSequence(g.seed, g.opt, MaxSteps(20))
+		s.Next()
+		previous := s.Current()
+		for s.Next() {
+			bumped := previous.IncrementBase().Eval().Int64()
+			value := s.Current().Eval().Int64()
+			if value >= bumped || (value > 0 && bumped-value != int64(subtracted(g.opt, int(s.Step().Int64())-1))) {
+				t.Errorf("seed %v: got %v at step %v from %v", g.seed, value, s.Step(), bumped)
+			}
+			previous = s.Current()
+		}
+		if s.Err() != nil {
+			t.Errorf("seed %v: unexpected error: %v", g.seed, s.Err())
+		}
+	}
+}
+
+// subtracted returns the number subtracted at the given step with the option.
+func subtracted(opt Option, step int) int {
+	var c config
+	opt(&c)
+	return c.subtract(step)
+}
+
+func TestSubtractFuncError(t *testing.T) {
+	s, _ := NewSequence(4, SubtractFunc(func(int) int { return 0 }))
+	s.Next()
+	if s.Next() || s.Err() == nil {
+		t.Errorf("expecting an error when subtracting zero")
+	}
+}
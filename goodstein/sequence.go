@@ -0,0 +1,194 @@
+package goodstein
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// Sequence iterates over the Goodstein sequence of a seed.
+// Like bufio.Scanner, successive calls to Next advance the sequence,
+// the first call yielding the seed itself at step 0:
+//
+//	s, _ := NewSequence(3)
+//	for s.Next() {
+//		fmt.Println(s.Step(), s.Base(), s.Current())
+//	}
+//
+// Sequences may be extremely long, and their decompositions extremely large,
+// so callers usually stop iterating before the end.
+// Steps and bases are *big.Int since they grow without any bound.
+type Sequence struct {
+	seed    int
+	step    *big.Int
+	base    *big.Int
+	d       decomposition.Decomposition
+	started bool
+	err     error
+	config
+}
+
+// config is the configuration of a sequence set by options.
+type config struct {
+	maxSteps int                // negative if unlimited
+	bump     BaseRule           // nil for the standard rule
+	subtract func(step int) int // nil to subtract one
+}
+
+// Option configures a sequence.
+type Option func(*config)
+
+// MaxSteps limits the sequence to steps 0 to n:
+// Next returns false after step n even if zero has not been reached.
+// n must not be negative.
+func MaxSteps(n int) Option {
+	if n < 0 {
+		panic("goodstein: negative maximum number of steps")
+	}
+	return func(c *config) { c.maxSteps = n }
+}
+
+// NewSequence returns the Goodstein sequence of seed,
+// which must not be negative, configured by the options.
+func NewSequence(seed int, opts ...Option) (*Sequence, error) {
+	const base = 2 // initial base
+	d, err := decomposition.New(base, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Sequence{seed: seed, step: new(big.Int), base: big.NewInt(base), d: d, config: config{maxSteps: -1}}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+	return s, nil
+}
+
+// NewSequenceFrom returns the Goodstein sequence going on from decomposition d at step i,
+// which must not be negative, configured by the options:
+// the first call to Next yields d itself at step i.
+// It is typically used to resume a sequence from a saved step.
+// Its seed is unknown, the maximum number of steps being counted from step 0 nonetheless.
+func NewSequenceFrom(d decomposition.Decomposition, i *big.Int, opts ...Option) (*Sequence, error) {
+	if i.Sign() < 0 {
+		return nil, fmt.Errorf("negative step %v", i)
+	}
+
+	s := &Sequence{seed: -1, step: new(big.Int).Set(i), base: d.Base(), d: d, config: config{maxSteps: -1}}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+	return s, nil
+}
+
+// Seed returns the seed of the sequence,
+// or -1 if it was started from a later step by NewSequenceFrom.
+func (s *Sequence) Seed() int {
+	return s.seed
+}
+
+// Next advances the sequence to its next step, which is then available through Current.
+// It returns false when the sequence is done, i.e. after zero has been reached,
+// or when the maximum number of steps has been reached.
+// It also returns false if the base rule or the subtraction fails, see Err.
+func (s *Sequence) Next() bool {
+	if !s.started {
+		s.started = true
+		return true
+	}
+	if s.err != nil || s.d.IsZero() || s.maxSteps >= 0 && s.step.Cmp(big.NewInt(int64(s.maxSteps))) >= 0 {
+		return false
+	}
+
+	// a new step and base, so that previously returned ones are left unchanged
+	next := new(big.Int).Add(s.step, one)
+
+	// standard rules
+	if s.bump == nil && s.subtract == nil {
+		s.d = s.d.IncrementBase().Decrement()
+		s.base = new(big.Int).Add(s.base, one)
+		s.step = next
+		return true
+	}
+
+	// custom rules only deal with int steps (and bases)
+	if !s.step.IsInt64() || s.step.Int64() > math.MaxInt {
+		s.err = fmt.Errorf("step %v is too large for custom rules", s.step)
+		return false
+	}
+	step := int(s.step.Int64())
+
+	// the base must increase, which also detects overflows
+	var (
+		bumped decomposition.Decomposition
+		base   *big.Int
+	)
+	if s.bump == nil {
+		bumped, base = s.d.IncrementBase(), new(big.Int).Add(s.base, one)
+	} else {
+		if !s.base.IsInt64() || s.base.Int64() >= math.MaxInt {
+			s.err = fmt.Errorf("base %v is too large for base rule", s.base)
+			return false
+		}
+		current := int(s.base.Int64())
+		b := s.bump(step, current)
+		if b <= current {
+			s.err = fmt.Errorf("base rule at step %v: base %v is not greater than %v", s.step, b, current)
+			return false
+		}
+		var err error
+		if bumped, err = s.d.WithBase(b); err != nil {
+			s.err = err
+			return false
+		}
+		base = big.NewInt(int64(b))
+	}
+
+	// and something must be subtracted
+	c := 1
+	if s.subtract != nil {
+		c = s.subtract(step)
+	}
+	if c < 1 {
+		s.err = fmt.Errorf("subtraction at step %v: %v is not positive", s.step, c)
+		return false
+	}
+	s.d = bumped.SubScalar(c)
+	s.base = base
+	s.step = next
+	return true
+}
+
+// one is the increment of steps and bases.
+var one = big.NewInt(1)
+
+// Err returns the error which stopped the sequence, if any.
+func (s *Sequence) Err() error {
+	return s.err
+}
+
+// Step returns the index of the current step, 0 being the seed.
+// The returned value is a copy which can be freely modified.
+func (s *Sequence) Step() *big.Int {
+	return new(big.Int).Set(s.step)
+}
+
+// Base returns the base of the current decomposition.
+// The returned value is a copy which can be freely modified.
+func (s *Sequence) Base() *big.Int {
+	return new(big.Int).Set(s.base)
+}
+
+// Current returns the decomposition of the current step.
+func (s *Sequence) Current() decomposition.Decomposition {
+	return s.d
+}
+
+// Done returns true if the sequence has reached zero,
+// after which Next returns false.
+// It returns false if the sequence was stopped by MaxSteps before zero.
+func (s *Sequence) Done() bool {
+	return s.d.IsZero()
+}
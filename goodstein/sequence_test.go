@@ -0,0 +1,144 @@
+package goodstein
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+func ExampleSequence() {
+	s, _ := NewSequence(3)
+	for s.Next() {
+		fmt.Println(s.Step(), s.Base(), s.Current())
+	}
+	// Output:
+	// 0 2 2 + 1
+	// 1 3 3
+	// 2 4 3
+	// 3 5 2
+	// 4 6 1
+	// 5 7 0
+}
+
+func TestSequence(t *testing.T) {
+	for seed := 0; seed <= 3; seed++ {
+		s, err := NewSequence(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Seed() != seed {
+			t.Errorf("got seed %v, expecting %v", s.Seed(), seed)
+		}
+
+		steps := -1
+		for s.Next() {
+			steps++
+			if s.Step().Int64() != int64(steps) {
+				t.Errorf("seed %v: got step %v, expecting %v", seed, s.Step(), steps)
+			}
+			if s.Base().Int64() != int64(steps+2) || s.Current().Base().Cmp(s.Base()) != 0 {
+				t.Errorf("seed %v: wrong base %v at step %v", seed, s.Base(), steps)
+			}
+		}
+		if !s.Done() {
+			t.Errorf("seed %v: sequence is not done", seed)
+		}
+		if s.Next() {
+			t.Errorf("seed %v: next step after zero", seed)
+		}
+
+		// consistent with the exact length
+		length, _, _ := Length(seed)
+		if length.Int64() != int64(steps) {
+			t.Errorf("seed %v: got %v steps, expecting %v", seed, steps, length)
+		}
+	}
+}
+
+func TestSequenceNotDone(t *testing.T) {
+	s, _ := NewSequence(4)
+	for i := 0; i < 100; i++ {
+		if !s.Next() {
+			t.Fatalf("sequence of 4 done after %v steps", i)
+		}
+	}
+	if s.Done() {
+		t.Errorf("sequence of 4 done after 100 steps")
+	}
+}
+
+func TestNewSequenceNegative(t *testing.T) {
+	if _, err := NewSequence(-1); err == nil {
+		t.Errorf("expecting an error for a negative seed")
+	}
+}
+
+func TestNewSequenceFrom(t *testing.T) {
+	for _, opts := range [][]Option{
+		nil,
+		{Bump(AddBase(2))},
+		{Bump(AddBase(3)), Subtract(3)},
+		{Subtract(2)},
+	} {
+		// a sequence resumed at any step goes on like the whole sequence
+		whole, _ := NewSequence(4, opts...)
+		for k := 0; k < 10 && whole.Next(); k++ {
+			s, err := NewSequenceFrom(whole.Current(), whole.Step(), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Seed() != -1 {
+				t.Errorf("got seed %v, expecting -1", s.Seed())
+			}
+			compared, _ := NewSequence(4, opts...)
+			for compared.Next() && compared.Step().Cmp(whole.Step()) < 0 {
+			}
+			for n := 0; n < 20; n++ {
+				next, expected := s.Next(), n == 0 || compared.Next()
+				if next != expected {
+					t.Fatalf("from step %v: got next %v, expecting %v", whole.Step(), next, expected)
+				}
+				if !next {
+					break
+				}
+				if s.Step().Cmp(compared.Step()) != 0 || s.Base().Cmp(compared.Base()) != 0 || !s.Current().Equal(compared.Current()) {
+					t.Errorf("from step %v: got %v %v %v, expecting %v %v %v", whole.Step(),
+						s.Step(), s.Base(), s.Current(), compared.Step(), compared.Base(), compared.Current())
+				}
+			}
+		}
+	}
+}
+
+func TestNewSequenceFromBigBase(t *testing.T) {
+	// bases beyond int are only bumped by the standard rule
+	base := new(big.Int).Lsh(big.NewInt(1), 100)
+	d := decomposition.NewBuilderBig(base).Add(7, decomposition.Decomposition{}).MustBuild()
+	step := new(big.Int).Lsh(big.NewInt(1), 70)
+	for _, g := range []struct {
+		opts  []Option
+		value int64 // of the next step, -1 for an error
+	}{
+		{nil, 6},
+		{[]Option{Subtract(3)}, -1}, // the step does not fit in an int
+		{[]Option{Bump(AddBase(1))}, -1},
+	} {
+		s, _ := NewSequenceFrom(d, step, g.opts...)
+		s.Next()
+		next := s.Next()
+		switch {
+		case g.value < 0 && (next || s.Err() == nil):
+			t.Errorf("%v options: expecting an error", len(g.opts))
+		case g.value >= 0 && !next:
+			t.Errorf("%v options: unexpected error %v", len(g.opts), s.Err())
+		case g.value >= 0 && s.Current().Eval().Int64() != g.value:
+			t.Errorf("%v options: got %v, expecting %v", len(g.opts), s.Current(), g.value)
+		}
+	}
+
+	if _, err := NewSequenceFrom(d, big.NewInt(-1)); err == nil {
+		t.Errorf("expecting an error for a negative step")
+	}
+}
@@ -0,0 +1,33 @@
+package goodstein
+
+import (
+	"iter"
+	"math/big"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// Steps returns an iterator over the steps of the Goodstein sequence of seed,
+// configured by the options, yielding the index of each step and its decomposition:
+//
+//	for i, d := range goodstein.Steps(7, goodstein.MaxSteps(10)) {
+//		fmt.Println(i, d)
+//	}
+//
+// The sequence of seeds beyond 3 is far too long to be iterated until zero,
+// so the loop must be broken or the number of steps limited with MaxSteps.
+// Iteration also stops if the base rule fails, use a Sequence to get the error.
+// It panics if seed is negative.
+func Steps(seed int, opts ...Option) iter.Seq2[*big.Int, decomposition.Decomposition] {
+	if seed < 0 {
+		panic("goodstein: negative seed")
+	}
+	return func(yield func(*big.Int, decomposition.Decomposition) bool) {
+		s, _ := NewSequence(seed, opts...)
+		for s.Next() {
+			if !yield(s.Step(), s.Current()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package goodstein
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleSteps() {
+	for i, d := range Steps(4, MaxSteps(3)) {
+		fmt.Println(i, d)
+	}
+	// Output:
+	// 0 2 ^ (2)
+	// 1 2 * 3 ^ (2) + 2 * 3 + 2
+	// 2 2 * 4 ^ (2) + 2 * 4 + 1
+	// 3 2 * 5 ^ (2) + 2 * 5
+}
+
+func TestSteps(t *testing.T) {
+	// same steps as the sequence
+	s, _ := NewSequence(3)
+	for i, d := range Steps(3) {
+		if !s.Next() {
+			t.Fatalf("too many steps")
+		}
+		if i.Cmp(s.Step()) != 0 || !d.Equal(s.Current()) {
+			t.Errorf("got step %v %q, expecting %v %q", i, d, s.Step(), s.Current())
+		}
+	}
+	if s.Next() {
+		t.Errorf("missing steps")
+	}
+}
+
+func TestStepsBreak(t *testing.T) {
+	n := 0
+	for i := range Steps(4) {
+		if i.Int64() == 10 {
+			break
+		}
+		n++
+	}
+	if n != 10 {
+		t.Errorf("got %v steps before break, expecting 10", n)
+	}
+}
+
+func TestMaxSteps(t *testing.T) {
+	for _, g := range []struct {
+		seed, max, steps int
+	}{
+		{3, 0, 1},
+		{3, 2, 3},
+		{3, 5, 6},
+		{3, 10, 6}, // zero reached first
+		{4, 10, 11},
+	} {
+		n := 0
+		for range Steps(g.seed, MaxSteps(g.max)) {
+			n++
+		}
+		if n != g.steps {
+			t.Errorf("seed %v with at most %v steps: got %v steps, expecting %v", g.seed, g.max, n, g.steps)
+		}
+	}
+}
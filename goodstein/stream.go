@@ -0,0 +1,42 @@
+package goodstein
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// Step is a step of a Goodstein sequence.
+type Step struct {
+	Index         *big.Int // 0 for the seed
+	Base          *big.Int
+	Decomposition decomposition.Decomposition
+}
+
+// Stream returns a channel producing the steps of the Goodstein sequence of seed,
+// configured by the options.
+// The channel is unbuffered: the next step is only computed once the previous one
+// has been received, so slow consumers are not overrun.
+// It is closed when the sequence is done or when ctx is cancelled,
+// whichever comes first.
+// It panics if seed is negative.
+func Stream(ctx context.Context, seed int, opts ...Option) <-chan Step {
+	s, err := NewSequence(seed, opts...)
+	if err != nil {
+		panic("goodstein: negative seed")
+	}
+
+	steps := make(chan Step)
+	go func() {
+		defer close(steps)
+		for ctx.Err() == nil && s.Next() {
+			select {
+			case steps <- Step{s.Step(), s.Base(), s.Current()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return steps
+}
@@ -0,0 +1,51 @@
+package goodstein
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func ExampleStream() {
+	for step := range Stream(context.Background(), 3) {
+		fmt.Println(step.Index, step.Base, step.Decomposition)
+	}
+	// Output:
+	// 0 2 2 + 1
+	// 1 3 3
+	// 2 4 3
+	// 3 5 2
+	// 4 6 1
+	// 5 7 0
+}
+
+func TestStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	steps := Stream(ctx, 4)
+	for i := 0; i < 10; i++ {
+		step := <-steps
+		if step.Index.Int64() != int64(i) {
+			t.Errorf("got step %v, expecting %v", step.Index, i)
+		}
+	}
+	cancel()
+
+	// the channel is closed, possibly after a step which was being sent
+	n := 0
+	for range steps {
+		n++
+	}
+	if n > 1 {
+		t.Errorf("got %v steps after cancellation", n)
+	}
+}
+
+func TestStreamMaxSteps(t *testing.T) {
+	n := 0
+	for range Stream(context.Background(), 4, MaxSteps(20)) {
+		n++
+	}
+	if n != 21 {
+		t.Errorf("got %v steps, expecting 21", n)
+	}
+}
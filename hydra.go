@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/batiazinga/goodstein/hydra"
+)
+
+// strategies are the strategies of Hercules, by name.
+// Random strategies are built from a random number generator.
+var strategies = map[string]func(rng *rand.Rand) hydra.Strategy{
+	"leftmost":  func(*rand.Rand) hydra.Strategy { return hydra.Leftmost },
+	"rightmost": func(*rand.Rand) hydra.Strategy { return hydra.Rightmost },
+	"random":    hydra.Random,
+}
+
+// hydraCommand plays hydra games.
+// A single game is printed chop by chop, several games are summarized one per line.
+func hydraCommand(args []string) error {
+	flags := flag.NewFlagSet("hydra", flag.ExitOnError)
+	strategy := flags.String("strategy", "leftmost", "strategy of Hercules: leftmost, rightmost or random")
+	maxIt := flags.Int("it", 1000, "maximum number of chops of each game")
+	games := flags.Int("games", 1, "number of games, only summarized if more than one")
+	seed := flags.Int64("seed", 1, "seed of the random strategy")
+	dotDir := flags.String("dot", "", "if not empty, directory where the hydra is written as <step>.dot after each chop of a single game")
+	svgDir := flags.String("svg", "", "if not empty, directory where the hydra is drawn as <step>.svg after each chop of a single game")
+	tikzDir := flags.String("tikz", "", "if not empty, directory where the hydra is drawn with LaTeX as <step>.tex after each chop of a single game")
+	animation := flags.String("animation", "", "if not empty, LaTeX file where the battle of a single game is drawn as an animation, one frame per chop")
+	fps := flags.Int("fps", 2, "frames per second of the animation")
+	certify := flags.String("certificate", "", "if not empty, file where a termination certificate of a single game is written: every hydra with its ordinal, asserted to strictly decrease")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expecting one hydra, e.g. \"(()(()))\"")
+	}
+	if *maxIt < 0 {
+		return fmt.Errorf("it must be positive")
+	}
+	if *games < 1 {
+		return fmt.Errorf("games must be at least 1")
+	}
+	if *games > 1 && (*dotDir != "" || *svgDir != "" || *tikzDir != "" || *animation != "" || *certify != "") {
+		return fmt.Errorf("dot, svg, tikz, animation and certificate are only available for a single game")
+	}
+	if *fps < 1 {
+		return fmt.Errorf("fps must be at least 1")
+	}
+	for _, dir := range []string{*dotDir, *svgDir, *tikzDir} {
+		if dir != "" {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return err
+			}
+		}
+	}
+
+	h, err := hydra.Parse(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid hydra: %v", err)
+	}
+	newStrategy, ok := strategies[*strategy]
+	if !ok {
+		return fmt.Errorf("unknown strategy %q", *strategy)
+	}
+	rng := rand.New(rand.NewSource(*seed))
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	// play a single game chop by chop
+	if *games == 1 {
+		// write a termination certificate (or not)
+		var cert io.Writer
+		if *certify != "" {
+			f, err := createOutput(*certify, false)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			cert = f
+			fmt.Fprintln(cert, "# hydra termination certificate: step hydra ordinal")
+		}
+
+		fmt.Fprintln(w, "step nodes hydra")
+		g := hydra.NewGame(h, newStrategy(rng))
+		var (
+			previous *hydra.Hydra
+			frames   []*hydra.Hydra // of the animation
+		)
+		for {
+			fmt.Fprintf(w, "%v %v %v\n", g.Step(), g.Hydra().NumNodes(), g.Hydra())
+			if err := drawHydra(g, *dotDir, *svgDir, *tikzDir); err != nil {
+				return err
+			}
+			if *animation != "" {
+				frames = append(frames, g.Hydra().Copy())
+			}
+			if cert != nil {
+				if previous != nil {
+					if err := hydra.CheckChop(previous, g.Hydra()); err != nil {
+						return fmt.Errorf("invalid certificate at step %v: %v", g.Step(), err)
+					}
+				}
+				previous = g.Hydra().Copy()
+				fmt.Fprintf(cert, "%v %v %q\n", g.Step(), g.Hydra(), g.Hydra().Ordinal())
+			}
+			if g.Step() == *maxIt || !g.Next() {
+				break
+			}
+		}
+		if *animation != "" {
+			if err := os.WriteFile(*animation, []byte(hydra.Animation(frames, *fps)+"\n"), 0644); err != nil {
+				return err
+			}
+		}
+		if cert != nil {
+			if g.Hydra().IsDead() {
+				fmt.Fprintln(cert, "# dead: ordinals strictly decreased down to 0")
+			} else {
+				fmt.Fprintln(cert, "# not dead: ordinals strictly decreased so far")
+			}
+		}
+		return nil
+	}
+
+	// or summarize several games
+	won := 0
+	fmt.Fprintln(w, "game steps dead")
+	for i := 1; i <= *games; i++ {
+		steps, dead := hydra.Play(h, newStrategy(rng), *maxIt)
+		if dead {
+			won++
+		}
+		fmt.Fprintf(w, "%v %v %v\n", i, steps, dead)
+	}
+	fmt.Fprintf(w, "# hydra killed in %v games out of %v\n", won, *games)
+	return nil
+}
+
+// drawHydra writes the current hydra of the game as <step>.dot in dotDir,
+// <step>.svg in svgDir and <step>.tex in tikzDir, if not empty.
+func drawHydra(g *hydra.Game, dotDir, svgDir, tikzDir string) error {
+	name := strconv.Itoa(g.Step())
+	if dotDir != "" {
+		if err := os.WriteFile(filepath.Join(dotDir, name+".dot"), []byte(g.Hydra().DOT()+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	if svgDir != "" {
+		if err := os.WriteFile(filepath.Join(svgDir, name+".svg"), []byte(g.Hydra().SVG()+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	if tikzDir != "" {
+		if err := os.WriteFile(filepath.Join(tikzDir, name+".tex"), []byte(g.Hydra().TikZ()+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+/*
+Package hydra implements the hydra game of Kirby and Paris.
+
+A hydra is a rooted tree whose leaves, other than the root, are its heads.
+Hercules chops one head at each step. At step n, if the head is not attached to the root,
+its parent, without the chopped head, regrows n copies of itself
+attached to the grandparent of the head.
+Whatever the strategy of Hercules, the hydra eventually dies, i.e. is reduced to its root,
+which like the termination of Goodstein sequences cannot be proved in Peano arithmetic.
+*/
+package hydra
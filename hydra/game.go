@@ -0,0 +1,98 @@
+package hydra
+
+import "math/rand"
+
+// Strategy chooses the head Hercules chops next, as a path (see Heads).
+// The hydra is not dead.
+type Strategy func(h *Hydra) []int
+
+// Leftmost chops the leftmost head.
+func Leftmost(h *Hydra) []int {
+	var path []int
+	for !h.IsDead() {
+		path = append(path, 0)
+		h = h.children[0]
+	}
+	return path
+}
+
+// Rightmost chops the rightmost head.
+func Rightmost(h *Hydra) []int {
+	var path []int
+	for !h.IsDead() {
+		path = append(path, len(h.children)-1)
+		h = h.children[len(h.children)-1]
+	}
+	return path
+}
+
+// Random returns the strategy chopping a head chosen uniformly at random.
+// It is deterministic for a given rng state.
+func Random(rng *rand.Rand) Strategy {
+	return func(h *Hydra) []int {
+		heads := h.Heads()
+		return heads[rng.Intn(len(heads))]
+	}
+}
+
+// Game is a battle between Hercules, following a strategy, and a hydra.
+// Like bufio.Scanner, successive calls to Next advance the game by one chop:
+//
+//	g := NewGame(h, Leftmost)
+//	for g.Next() {
+//		fmt.Println(g.Step(), g.Hydra())
+//	}
+type Game struct {
+	hydra    *Hydra
+	strategy Strategy
+	step     int
+}
+
+// NewGame returns a game against a copy of the hydra.
+func NewGame(h *Hydra, s Strategy) *Game {
+	return &Game{hydra: h.Copy(), strategy: s}
+}
+
+// Next chops a head, regrowing step copies at the step-th chop.
+// It returns false if the hydra is already dead.
+func (g *Game) Next() bool {
+	if g.hydra.IsDead() {
+		return false
+	}
+	g.step++
+	if err := g.hydra.Chop(g.strategy(g.hydra), g.step); err != nil {
+		panic("hydra: strategy chose an invalid head: " + err.Error())
+	}
+	return true
+}
+
+// Step returns the number of chops so far.
+func (g *Game) Step() int {
+	return g.step
+}
+
+// Hydra returns the current hydra, which must not be modified.
+func (g *Game) Hydra() *Hydra {
+	return g.hydra
+}
+
+// Play plays a game for at most maxSteps chops
+// and returns the number of chops and whether the hydra is dead.
+func Play(h *Hydra, s Strategy, maxSteps int) (steps int, dead bool) {
+	g := NewGame(h, s)
+	for g.Step() < maxSteps && g.Next() {
+	}
+	return g.Step(), g.Hydra().IsDead()
+}
+
+// Frames plays a game for at most maxSteps chops
+// and returns copies of the hydra before the first chop and after each chop,
+// e.g. to draw the battle frame by frame (see Animation).
+func Frames(h *Hydra, s Strategy, maxSteps int) []*Hydra {
+	g := NewGame(h, s)
+	frames := []*Hydra{g.Hydra().Copy()}
+	for g.Step() < maxSteps && g.Next() {
+		frames = append(frames, g.Hydra().Copy())
+	}
+	return frames
+}
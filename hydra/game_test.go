@@ -0,0 +1,112 @@
+package hydra
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func ExampleGame() {
+	h, _ := Parse("(((())))")
+	g := NewGame(h, Rightmost)
+	for g.Next() && g.Step() <= 3 {
+		fmt.Println(g.Step(), g.Hydra())
+	}
+	// Output:
+	// 1 ((()()))
+	// 2 ((())(())(()))
+	// 3 ((())(())()()()())
+}
+
+func TestStrategies(t *testing.T) {
+	h, _ := Parse("(()((())())())")
+	if head := fmt.Sprint(Leftmost(h)); head != "[0]" {
+		t.Errorf("leftmost: got %v", head)
+	}
+	if head := fmt.Sprint(Rightmost(h)); head != "[2]" {
+		t.Errorf("rightmost: got %v", head)
+	}
+	rng := rand.New(rand.NewSource(1))
+	random := Random(rng)
+	for i := 0; i < 20; i++ {
+		if _, err := h.node(random(h)); err != nil {
+			t.Errorf("random: %v", err)
+		}
+	}
+}
+
+func TestPlay(t *testing.T) {
+	for _, g := range []struct {
+		hydra    string
+		strategy Strategy
+		steps    int
+	}{
+		{"()", Leftmost, 0},
+		{"(()())", Leftmost, 2},
+		{"(())", Rightmost, 1},
+		{"((()))", Leftmost, 3},
+		{"((()))", Rightmost, 3},
+		{"((())())", Leftmost, 4},
+		{"(((())))", Rightmost, 37},
+	} {
+		h, _ := Parse(g.hydra)
+		steps, dead := Play(h, g.strategy, 1000)
+		if !dead || steps != g.steps {
+			t.Errorf("%v: got %v steps (dead: %v), expecting %v", g.hydra, steps, dead, g.steps)
+		}
+	}
+
+	// random battles also kill the hydra
+	rng := rand.New(rand.NewSource(1))
+	h, _ := Parse("((())(()))")
+	for i := 0; i < 10; i++ {
+		if _, dead := Play(h, Random(rng), 100000); !dead {
+			t.Errorf("random battle not won")
+		}
+	}
+}
+
+func TestPlayMaxSteps(t *testing.T) {
+	h, _ := Parse("((((()))))")
+	steps, dead := Play(h, Leftmost, 10)
+	if steps != 10 || dead {
+		t.Errorf("got %v steps (dead: %v), expecting 10 steps", steps, dead)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	for _, g := range []struct {
+		hydra    string
+		maxSteps int
+		frames   int
+		dead     bool
+	}{
+		{"()", 10, 1, true},
+		{"((()))", 10, 4, true},
+		{"((()))", 2, 3, false},
+		{"((((()))))", 0, 1, false},
+	} {
+		h, _ := Parse(g.hydra)
+		frames := Frames(h, Leftmost, g.maxSteps)
+		if len(frames) != g.frames || frames[len(frames)-1].IsDead() != g.dead {
+			t.Errorf("%v: got %v frames (dead: %v), expecting %v (dead: %v)", g.hydra, len(frames), frames[len(frames)-1].IsDead(), g.frames, g.dead)
+		}
+		if frames[0].String() != g.hydra {
+			t.Errorf("%v: got first frame %v", g.hydra, frames[0])
+		}
+		if h.String() != g.hydra {
+			t.Errorf("%v: hydra modified to %v", g.hydra, h)
+		}
+	}
+
+	// frames are independent copies of the successive hydras
+	h, _ := Parse("((())())")
+	frames := Frames(h, Leftmost, 1000)
+	g := NewGame(h, Leftmost)
+	for i, f := range frames {
+		if f.String() != g.Hydra().String() {
+			t.Errorf("frame %v: got %v, expecting %v", i, f, g.Hydra())
+		}
+		g.Next()
+	}
+}
@@ -0,0 +1,162 @@
+package hydra
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hydra is a node of a hydra, the whole hydra being its root.
+// Nodes without children are heads, except for the root.
+// The default value of Hydra is a dead hydra.
+type Hydra struct {
+	children []*Hydra
+}
+
+// Parse parses a hydra as printed by String:
+// each node is written as its children between parentheses,
+// e.g. "(()(()))" is a root with a head and a neck bearing a head.
+func Parse(s string) (*Hydra, error) {
+	s = strings.Join(strings.Fields(s), "")
+	h, rest, err := parseNode(s)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected %q after hydra", rest)
+	}
+	return h, nil
+}
+
+// parseNode parses a node at the beginning of s and returns the rest of s.
+func parseNode(s string) (*Hydra, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", fmt.Errorf("expecting '(' at %q", s)
+	}
+	s = s[1:]
+
+	h := new(Hydra)
+	for !strings.HasPrefix(s, ")") {
+		if s == "" {
+			return nil, "", fmt.Errorf("missing ')'")
+		}
+		child, rest, err := parseNode(s)
+		if err != nil {
+			return nil, "", err
+		}
+		h.children = append(h.children, child)
+		s = rest
+	}
+	return h, s[1:], nil
+}
+
+// String returns the hydra with each node written as its children between parentheses,
+// e.g. "(()(()))".
+func (h *Hydra) String() string {
+	var sb strings.Builder
+	h.write(&sb)
+	return sb.String()
+}
+
+// write writes the node to sb.
+func (h *Hydra) write(sb *strings.Builder) {
+	sb.WriteByte('(')
+	for _, c := range h.children {
+		c.write(sb)
+	}
+	sb.WriteByte(')')
+}
+
+// Copy returns a deep copy of the hydra.
+func (h *Hydra) Copy() *Hydra {
+	c := &Hydra{children: make([]*Hydra, len(h.children))}
+	for i, child := range h.children {
+		c.children[i] = child.Copy()
+	}
+	return c
+}
+
+// IsDead returns true if the hydra is reduced to its root.
+func (h *Hydra) IsDead() bool {
+	return len(h.children) == 0
+}
+
+// NumNodes returns the number of nodes of the hydra, including the root.
+func (h *Hydra) NumNodes() int {
+	n := 1
+	for _, c := range h.children {
+		n += c.NumNodes()
+	}
+	return n
+}
+
+// Heads returns the heads of the hydra from left to right.
+// Each head is given by its path: the indices of the children
+// followed from the root down to the head.
+func (h *Hydra) Heads() [][]int {
+	var heads [][]int
+	h.heads(nil, &heads)
+	return heads
+}
+
+// heads appends the heads below the node at path to heads.
+func (h *Hydra) heads(path []int, heads *[][]int) {
+	for i, c := range h.children {
+		p := append(path[:len(path):len(path)], i)
+		if c.IsDead() {
+			*heads = append(*heads, p)
+			continue
+		}
+		c.heads(p, heads)
+	}
+}
+
+// node returns the node at path.
+func (h *Hydra) node(path []int) (*Hydra, error) {
+	for _, i := range path {
+		if i < 0 || i >= len(h.children) {
+			return nil, fmt.Errorf("no node at %v", path)
+		}
+		h = h.children[i]
+	}
+	return h, nil
+}
+
+// Chop chops the head at path (see Heads), at step n:
+// if the head is not attached to the root, its parent, without the head,
+// regrows n copies of itself, attached to the grandparent of the head
+// right after the parent.
+// n must be non negative.
+func (h *Hydra) Chop(head []int, n int) error {
+	if n < 0 {
+		panic("hydra: negative step")
+	}
+	if len(head) == 0 {
+		return fmt.Errorf("the root is not a head")
+	}
+	node, err := h.node(head)
+	if err != nil {
+		return err
+	}
+	if !node.IsDead() {
+		return fmt.Errorf("node at %v is not a head", head)
+	}
+
+	// remove the head
+	parent, _ := h.node(head[:len(head)-1])
+	i := head[len(head)-1]
+	parent.children = append(parent.children[:i:i], parent.children[i+1:]...)
+	if len(head) == 1 {
+		return nil
+	}
+
+	// and regrow the parent
+	grandparent, _ := h.node(head[:len(head)-2])
+	j := head[len(head)-2]
+	regrown := make([]*Hydra, 0, len(grandparent.children)+n)
+	regrown = append(regrown, grandparent.children[:j+1]...)
+	for k := 0; k < n; k++ {
+		regrown = append(regrown, parent.Copy())
+	}
+	grandparent.children = append(regrown, grandparent.children[j+1:]...)
+	return nil
+}
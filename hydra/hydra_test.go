@@ -0,0 +1,100 @@
+package hydra
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleHydra_Chop() {
+	h, _ := Parse("(()(()))")
+	h.Chop([]int{1, 0}, 2)
+	fmt.Println(h)
+	// Output:
+	// (()()()())
+}
+
+func TestParseString(t *testing.T) {
+	for _, s := range []string{
+		"()",
+		"(())",
+		"(()())",
+		"(()(()))",
+		"((()())(()))",
+	} {
+		h, err := Parse(s)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", s, err)
+			continue
+		}
+		if h.String() != s {
+			t.Errorf("got %q, expecting %q", h, s)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"(",
+		"())",
+		"(()",
+		"x",
+		"()()",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%q: expecting an error", s)
+		}
+	}
+}
+
+func TestHeads(t *testing.T) {
+	h, _ := Parse("(()((())())())")
+	if heads := fmt.Sprint(h.Heads()); heads != "[[0] [1 0 0] [1 1] [2]]" {
+		t.Errorf("got heads %v", heads)
+	}
+	if n := h.NumNodes(); n != 7 {
+		t.Errorf("got %v nodes, expecting 7", n)
+	}
+}
+
+func TestChop(t *testing.T) {
+	for _, g := range []struct {
+		hydra    string
+		head     []int
+		n        int
+		expected string
+	}{
+		{"(()())", []int{1}, 5, "(())"},
+		{"((()))", []int{0, 0}, 3, "(()()()())"},
+		{"((()()))", []int{0, 1}, 1, "((())(()))"},
+		{"(((())))", []int{0, 0, 0}, 2, "((()()()))"},
+		{"(((()))())", []int{0, 0, 0}, 1, "((()())())"},
+	} {
+		h, _ := Parse(g.hydra)
+		if err := h.Chop(g.head, g.n); err != nil {
+			t.Errorf("chopping %v of %v: unexpected error: %v", g.head, g.hydra, err)
+			continue
+		}
+		if h.String() != g.expected {
+			t.Errorf("chopping %v of %v: got %v, expecting %v", g.head, g.hydra, h, g.expected)
+		}
+	}
+}
+
+func TestChopInvalid(t *testing.T) {
+	h, _ := Parse("((()))")
+	for _, head := range [][]int{nil, {0}, {1}, {0, 0, 0}} {
+		if err := h.Chop(head, 1); err == nil {
+			t.Errorf("chopping %v: expecting an error", head)
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	h, _ := Parse("((()))")
+	c := h.Copy()
+	c.Chop([]int{0, 0}, 1)
+	if h.String() != "((()))" {
+		t.Errorf("original hydra modified: %v", h)
+	}
+}
@@ -0,0 +1,74 @@
+package hydra
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/batiazinga/goodstein/decomposition"
+	"github.com/batiazinga/goodstein/ordinal"
+)
+
+// Ordinal returns the ordinal of the hydra:
+// 0 for a dead hydra, otherwise the natural sum of ω ^ a over its children,
+// where a is the ordinal of the child.
+// Each chop strictly decreases it, whatever the strategy, which is why Hercules wins.
+func (h *Hydra) Ordinal() ordinal.Ordinal {
+	exponents := make([]ordinal.Ordinal, len(h.children))
+	for i, c := range h.children {
+		exponents[i] = c.Ordinal()
+	}
+
+	// natural sum: from the greatest to the lowest term, so that none is absorbed
+	sort.Slice(exponents, func(i, j int) bool { return exponents[i].Cmp(exponents[j]) > 0 })
+	terms := make([]ordinal.Ordinal, len(exponents))
+	for i, e := range exponents {
+		terms[i] = ordinal.OmegaPow(e)
+	}
+	return ordinal.Sum(terms...)
+}
+
+// Decomposition returns the hereditary base-b decomposition obtained by replacing ω by b
+// in the ordinal of the hydra, e.g. 2 ^ (2) + 1 for "(((()))())" in base 2.
+// It returns an error if a node has b or more identical children,
+// since the coefficient of their monome would not be a base-b digit.
+func (h *Hydra) Decomposition(b int) (decomposition.Decomposition, error) {
+	// group identical children into a single monome
+	var (
+		exponents []decomposition.Decomposition
+		coeffs    []int
+	)
+children:
+	for _, c := range h.children {
+		e, err := c.Decomposition(b)
+		if err != nil {
+			return decomposition.Decomposition{}, err
+		}
+		for i := range exponents {
+			if exponents[i].Equal(e) {
+				coeffs[i]++
+				continue children
+			}
+		}
+		exponents = append(exponents, e)
+		coeffs = append(coeffs, 1)
+	}
+
+	bd := decomposition.NewBuilder(b)
+	for i, e := range exponents {
+		bd.Add(coeffs[i], e)
+	}
+	d, err := bd.Build()
+	if err != nil {
+		return decomposition.Decomposition{}, fmt.Errorf("hydra %v in base %v: %w", h, b, err)
+	}
+	return d, nil
+}
+
+// CheckChop returns an error if the ordinal of after is not lower than the one of before,
+// which never happens when after results from a chop of before.
+func CheckChop(before, after *Hydra) error {
+	if a, b := after.Ordinal(), before.Ordinal(); a.Cmp(b) >= 0 {
+		return fmt.Errorf("ordinal %v of %v is not lower than ordinal %v of %v", a, after, b, before)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package hydra
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+func ExampleHydra_Ordinal() {
+	h, _ := Parse("(((()))())")
+	fmt.Println(h.Ordinal())
+	// Output:
+	// ω ^ (ω) + 1
+}
+
+func TestOrdinal(t *testing.T) {
+	for _, g := range []struct {
+		hydra, ordinal string
+	}{
+		{"()", "0"},
+		{"(())", "1"},
+		{"(()()())", "3"},
+		{"((()))", "ω"},
+		{"(()(()))", "ω + 1"},
+		{"((()())(()))", "ω ^ (2) + ω"},
+		{"(((())))", "ω ^ (ω)"},
+	} {
+		h, _ := Parse(g.hydra)
+		if s := h.Ordinal().String(); s != g.ordinal {
+			t.Errorf("%v: got %q, expecting %q", g.hydra, s, g.ordinal)
+		}
+	}
+}
+
+func TestDecomposition(t *testing.T) {
+	for _, g := range []struct {
+		hydra    string
+		b        int
+		expected string
+	}{
+		{"()", 2, "0"},
+		{"(())", 2, "1"},
+		{"(((()))())", 2, "2 ^ (2) + 1"},
+		{"(()()())", 4, "3"},
+		{"((()())(()))", 3, "3 ^ (2) + 3"},
+	} {
+		h, _ := Parse(g.hydra)
+		d, err := h.Decomposition(g.b)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", g.hydra, err)
+			continue
+		}
+		if d.String() != g.expected {
+			t.Errorf("%v in base %v: got %q, expecting %q", g.hydra, g.b, d, g.expected)
+		}
+		// same ordinal
+		if d.ToOrdinal().Cmp(h.Ordinal()) != 0 {
+			t.Errorf("%v in base %v: ordinal %v, expecting %v", g.hydra, g.b, d.ToOrdinal(), h.Ordinal())
+		}
+	}
+
+	// too many identical children
+	h, _ := Parse("(()()())")
+	if _, err := h.Decomposition(3); !errors.Is(err, decomposition.ErrInvalidDecomposition) {
+		t.Errorf("got %v, expecting %v", err, decomposition.ErrInvalidDecomposition)
+	}
+}
+
+func TestChopDecreasesOrdinal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, s := range []string{"((())(()))", "(((())))", "((()())(())())"} {
+		h, _ := Parse(s)
+		for _, strategy := range []Strategy{Leftmost, Rightmost, Random(rng)} {
+			g := NewGame(h, strategy)
+			before := g.Hydra().Copy()
+			for g.Step() < 200 && g.Next() {
+				if err := CheckChop(before, g.Hydra()); err != nil {
+					t.Error(err)
+				}
+				before = g.Hydra().Copy()
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package hydra
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DOT returns the hydra as an undirected Graphviz graph,
+// drawn with the root at the bottom and the heads as circles.
+func (h *Hydra) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("graph hydra {\n")
+	sb.WriteString("  rankdir=BT;\n")
+	sb.WriteString("  node [shape=point];\n")
+	sb.WriteString("  n0 [shape=box, width=0.2, height=0.1, label=\"\"];\n")
+	id := 0
+	h.dot(&sb, &id)
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// dot writes the edges from the node, whose identifier is *id, to its children,
+// which are numbered in depth-first order.
+func (h *Hydra) dot(sb *strings.Builder, id *int) {
+	parent := *id
+	for _, c := range h.children {
+		*id++
+		if c.IsDead() {
+			fmt.Fprintf(sb, "  n%v [shape=circle, width=0.15, label=\"\"];\n", *id)
+		}
+		fmt.Fprintf(sb, "  n%v -- n%v;\n", parent, *id)
+		c.dot(sb, id)
+	}
+}
+
+// Layout of SVG drawings.
+const (
+	svgSpacing = 20.0 // between heads and between levels
+	svgRadius  = 4.0  // of heads
+	svgMargin  = 10.0
+)
+
+// svgNode is a node placed in an SVG drawing.
+type svgNode struct {
+	x, y float64
+}
+
+// svgLayout places the nodes of a hydra: heads from left to right
+// and other nodes centered above their children.
+type svgLayout struct {
+	heads  int // number of placed heads
+	height int // number of levels
+	edges  [][2]svgNode
+	circle []svgNode
+}
+
+// place places the node at the given depth and returns its position.
+// Levels are numbered from the root and flipped once the height is known.
+func (l *svgLayout) place(h *Hydra, depth int) svgNode {
+	l.height = max(l.height, depth+1)
+	if h.IsDead() && depth > 0 {
+		n := svgNode{float64(l.heads) * svgSpacing, float64(depth) * svgSpacing}
+		l.heads++
+		l.circle = append(l.circle, n)
+		return n
+	}
+
+	var children []svgNode
+	for _, c := range h.children {
+		children = append(children, l.place(c, depth+1))
+	}
+	n := svgNode{float64(l.heads) * svgSpacing, float64(depth) * svgSpacing}
+	if len(children) > 0 {
+		n.x = (children[0].x + children[len(children)-1].x) / 2
+	}
+	for _, c := range children {
+		l.edges = append(l.edges, [2]svgNode{n, c})
+	}
+	return n
+}
+
+// SVG returns a standalone SVG document drawing the hydra
+// with the root at the bottom and the heads as circles.
+func (h *Hydra) SVG() string {
+	var l svgLayout
+	root := l.place(h, 0)
+
+	// flip levels so that the root is at the bottom
+	w := float64(max(l.heads, 1)-1)*svgSpacing + 2*svgMargin
+	height := float64(l.height-1)*svgSpacing + 2*svgMargin
+	flip := func(x, y float64) (float64, float64) {
+		return x + svgMargin, height - svgMargin - y
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %.2f %.2f" width="%.2f" height="%.2f">`, w, height, w, height)
+	sb.WriteString("\n")
+	sb.WriteString(`<g stroke="black" fill="black">`)
+	sb.WriteString("\n")
+	for _, e := range l.edges {
+		x1, y1 := flip(e[0].x, e[0].y)
+		x2, y2 := flip(e[1].x, e[1].y)
+		fmt.Fprintf(&sb, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f"/>`, x1, y1, x2, y2)
+		sb.WriteString("\n")
+	}
+	for _, c := range l.circle {
+		x, y := flip(c.x, c.y)
+		fmt.Fprintf(&sb, `<circle cx="%.2f" cy="%.2f" r="%.2f" fill="white"/>`, x, y, svgRadius)
+		sb.WriteString("\n")
+	}
+	x, y := flip(root.x, root.y)
+	fmt.Fprintf(&sb, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f"/>`, x-svgRadius, y-svgRadius/2, 2*svgRadius, svgRadius)
+	sb.WriteString("\n")
+	sb.WriteString("</g>\n</svg>")
+	return sb.String()
+}
+
+// TikZ returns the hydra drawn as a tree with the forest LaTeX package, one node per line,
+// with the root at the bottom as a box, the heads as circles and other nodes as dots.
+// For instance, (()(())) is drawn as
+//
+//	% requires \usepackage{forest}
+//	\begin{forest}
+//	for tree={grow'=north, circle, draw, fill=black, inner sep=1.5pt, l sep=12pt, s sep=12pt}
+//	[, rectangle, minimum width=8pt
+//	  [, fill=white]
+//	  [
+//	    [, fill=white]
+//	  ]
+//	]
+//	\end{forest}
+func (h *Hydra) TikZ() string {
+	var sb strings.Builder
+	sb.WriteString("% requires \\usepackage{forest}\n")
+	h.forest(&sb)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// forest writes the forest environment drawing the hydra, followed by a newline.
+func (h *Hydra) forest(sb *strings.Builder) {
+	sb.WriteString("\\begin{forest}\n")
+	sb.WriteString("for tree={grow'=north, circle, draw, fill=black, inner sep=1.5pt, l sep=12pt, s sep=12pt}\n")
+	if h.IsDead() {
+		writeForestNode(sb, "[, rectangle, minimum width=8pt]", 0)
+	} else {
+		writeForestNode(sb, "[, rectangle, minimum width=8pt", 0)
+		for _, c := range h.children {
+			c.writeForest(sb, 1)
+		}
+		writeForestNode(sb, "]", 0)
+	}
+	sb.WriteString("\\end{forest}\n")
+}
+
+// writeForest writes the tree of a node other than the root at the given depth.
+func (h *Hydra) writeForest(sb *strings.Builder, depth int) {
+	if h.IsDead() {
+		writeForestNode(sb, "[, fill=white]", depth)
+		return
+	}
+	writeForestNode(sb, "[", depth)
+	for _, c := range h.children {
+		c.writeForest(sb, depth+1)
+	}
+	writeForestNode(sb, "]", depth)
+}
+
+// writeForestNode writes a line of the tree indented by depth.
+func writeForestNode(sb *strings.Builder, s string, depth int) {
+	for i := 0; i < depth; i++ {
+		sb.WriteString("  ")
+	}
+	sb.WriteString(s)
+	sb.WriteString("\n")
+}
+
+// Animation returns the frames, e.g. the successive hydras of a battle (see Frames),
+// drawn like TikZ in an animateinline environment of the animate LaTeX package
+// showing fps frames per second, with controls.
+func Animation(frames []*Hydra, fps int) string {
+	var sb strings.Builder
+	sb.WriteString("% requires \\usepackage{forest} and \\usepackage{animate}\n")
+	fmt.Fprintf(&sb, "\\begin{animateinline}[controls, loop]{%v}\n", fps)
+	for i, h := range frames {
+		if i > 0 {
+			sb.WriteString("\\newframe\n")
+		}
+		h.forest(&sb)
+	}
+	sb.WriteString(`\end{animateinline}`)
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package hydra
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleHydra_DOT() {
+	h, _ := Parse("(()(()))")
+	fmt.Println(h.DOT())
+	// Output:
+	// graph hydra {
+	//   rankdir=BT;
+	//   node [shape=point];
+	//   n0 [shape=box, width=0.2, height=0.1, label=""];
+	//   n1 [shape=circle, width=0.15, label=""];
+	//   n0 -- n1;
+	//   n0 -- n2;
+	//   n3 [shape=circle, width=0.15, label=""];
+	//   n2 -- n3;
+	// }
+}
+
+func ExampleHydra_SVG() {
+	h, _ := Parse("(()(()))")
+	fmt.Println(h.SVG())
+	// Output:
+	// <svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 40.00 60.00" width="40.00" height="60.00">
+	// <g stroke="black" fill="black">
+	// <line x1="30.00" y1="30.00" x2="30.00" y2="10.00"/>
+	// <line x1="20.00" y1="50.00" x2="10.00" y2="30.00"/>
+	// <line x1="20.00" y1="50.00" x2="30.00" y2="30.00"/>
+	// <circle cx="10.00" cy="30.00" r="4.00" fill="white"/>
+	// <circle cx="30.00" cy="10.00" r="4.00" fill="white"/>
+	// <rect x="16.00" y="48.00" width="8.00" height="4.00"/>
+	// </g>
+	// </svg>
+}
+
+func TestSVG(t *testing.T) {
+	for _, g := range []struct {
+		hydra        string
+		lines, heads int
+	}{
+		{"()", 0, 0},
+		{"(())", 1, 1},
+		{"(()(()))", 3, 2},
+		{"((()())(()))", 5, 3},
+	} {
+		h, _ := Parse(g.hydra)
+		svg := h.SVG()
+		if n := strings.Count(svg, "<line"); n != g.lines {
+			t.Errorf("%v: got %v lines, expecting %v", g.hydra, n, g.lines)
+		}
+		if n := strings.Count(svg, "<circle"); n != g.heads {
+			t.Errorf("%v: got %v heads, expecting %v", g.hydra, n, g.heads)
+		}
+	}
+}
+
+func ExampleHydra_TikZ() {
+	h, _ := Parse("(()(()))")
+	fmt.Println(h.TikZ())
+	// Output:
+	// % requires \usepackage{forest}
+	// \begin{forest}
+	// for tree={grow'=north, circle, draw, fill=black, inner sep=1.5pt, l sep=12pt, s sep=12pt}
+	// [, rectangle, minimum width=8pt
+	//   [, fill=white]
+	//   [
+	//     [, fill=white]
+	//   ]
+	// ]
+	// \end{forest}
+}
+
+func TestTikZ(t *testing.T) {
+	for _, g := range []struct {
+		hydra        string
+		nodes, heads int
+	}{
+		{"()", 1, 0},
+		{"(())", 2, 1},
+		{"(()(()))", 4, 2},
+		{"((()())(()))", 6, 3},
+	} {
+		h, _ := Parse(g.hydra)
+		tikz := h.TikZ()
+		if n := strings.Count(tikz, "["); n != g.nodes {
+			t.Errorf("%v: got %v nodes, expecting %v", g.hydra, n, g.nodes)
+		}
+		if n := strings.Count(tikz, "]"); n != g.nodes {
+			t.Errorf("%v: got %v closed nodes, expecting %v", g.hydra, n, g.nodes)
+		}
+		if n := strings.Count(tikz, "fill=white"); n != g.heads {
+			t.Errorf("%v: got %v heads, expecting %v", g.hydra, n, g.heads)
+		}
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	h, _ := Parse("((()))")
+	frames := Frames(h, Leftmost, 100)
+	animation := Animation(frames, 2)
+	if !strings.HasPrefix(animation, "% requires \\usepackage{forest} and \\usepackage{animate}\n\\begin{animateinline}[controls, loop]{2}\n") {
+		t.Errorf("unexpected beginning of animation %q", animation)
+	}
+	if !strings.HasSuffix(animation, "\\end{forest}\n\\end{animateinline}") {
+		t.Errorf("unexpected end of animation %q", animation)
+	}
+	if n := strings.Count(animation, "\\begin{forest}"); n != len(frames) {
+		t.Errorf("got %v trees, expecting %v", n, len(frames))
+	}
+	if n := strings.Count(animation, "\\newframe"); n != len(frames)-1 {
+		t.Errorf("got %v new frames, expecting %v", n, len(frames)-1)
+	}
+	for i, f := range frames {
+		if !strings.Contains(animation, strings.TrimPrefix(f.TikZ(), "% requires \\usepackage{forest}\n")) {
+			t.Errorf("frame %v is not drawn like TikZ", i)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// exactDigits is the maximum number of digits of a value
+// printed as is in a longtable; larger values are estimated.
+const exactDigits = 12
+
+// writeLongtableBegin starts a LaTeX longtable of iterations.
+// The header is repeated at the top of each page.
+// It requires the longtable package.
+func writeLongtableBegin(w io.Writer) {
+	fmt.Fprintln(w, `% requires \usepackage{longtable}`)
+	fmt.Fprintln(w, `\begin{longtable}{rrrp{0.55\textwidth}}`)
+	fmt.Fprintln(w, `iteration & base & value & decomposition \\`)
+	fmt.Fprintln(w, `\hline`)
+	fmt.Fprintln(w, `\endhead`)
+}
+
+// writeLongtableRow writes an iteration as a row of a LaTeX longtable.
+// value is the decimal value of the decomposition, or "-" if not computed,
+// and latex is its LaTeX representation.
+func writeLongtableRow(w io.Writer, i, b *big.Int, value, latex string) {
+	fmt.Fprintf(w, "%v & %v & %v & $%v$ \\\\\n", i, b, estimate(value), latex)
+}
+
+// writeLongtableEnd ends a LaTeX longtable.
+func writeLongtableEnd(w io.Writer) {
+	fmt.Fprintln(w, `\end{longtable}`)
+}
+
+// estimate returns a LaTeX estimate of a decimal value:
+// small values are returned as is,
+// large ones in scientific notation, truncated to four significant digits.
+func estimate(value string) string {
+	if len(value) <= exactDigits {
+		return value
+	}
+	return fmt.Sprintf(`$%v.%v \times 10^{%v}$`, value[:1], value[1:4], len(value)-1)
+}
@@ -1,82 +1,680 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math"
+	"math/big"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/batiazinga/goodstein/decomposition"
+	"github.com/batiazinga/goodstein/goodstein"
 )
 
 var (
-	it     = flag.Int("it", 10, "maximum number of iterations")
-	latex  = flag.Bool("latex", false, "if true, results are valid LaTeX commands")
-	header = flag.Bool("header", true, "if true, a header is displayed")
+	it        = flag.Int("it", 10, "maximum number of iterations")
+	untilZero = flag.Bool("until-zero", false, "if true, iterations go on until the decomposition reaches 0 whatever it, bounded by max-steps and max-seconds only")
+	maxSteps  = flag.Int("max-steps", 0, "if positive, maximum number of steps, the program exiting with status 3 if it is reached before 0")
+	maxSecs   = flag.Float64("max-seconds", 0, "if positive, maximum duration of iterations in seconds, the program exiting with status 3 if it is reached before 0")
+	latex     = flag.Bool("latex", false, "if true, results are valid LaTeX commands")
+	header    = flag.Bool("header", true, "if true, a header is displayed")
+	summary   = flag.Bool("summary", false, "if true, iterations are not displayed and only a final summary is printed")
+	stats     = flag.Duration("stats", 0, "if positive, period of progress reports on stderr: iteration, steps, elapsed time, throughput, base, size and depth of the decomposition, memory usage and ETA")
+	statsJSON = flag.Bool("stats-json", false, "if true, progress reports are JSON objects, one per line")
+	stamps    = flag.Bool("timestamps", false, "if true, each iteration is prefixed with wall-clock time and elapsed seconds since start")
+	noValue   = flag.Bool("no-value", false, "if true, values are not computed, which is much faster for long runs")
+	appendf   = flag.String("append", "", "file to append iterations to, resuming from its last iteration if any (gzip-compressed if it ends with .gz)")
+	output    = flag.String("output", "", "file to write iterations to instead of stdout, gzip-compressed if it ends with .gz")
+	from      = flag.String("from", "", "if not empty, first iteration to report, previous ones being computed silently; it then counts iterations from there")
+	to        = flag.String("to", "", "if not empty, last iteration to report, iterations stopping there whatever it and until-zero")
+	sample    = flag.String("sample", "", "iterations to report: empty for all, 'exp' for 0, 1, 2, 4, 8, ... or a comma-separated list")
+	table     = flag.Bool("longtable", false, "if true, iterations are written as the rows of a LaTeX longtable")
+	document  = flag.Bool("document", false, "if true, a complete LaTeX document is written, with iterations in a longtable if longtable is true or in an align* environment otherwise")
+	stable    = flag.Bool("stretches", false, "if true, maximal stretches of iterations with the same shape (ignoring the constant monome) are reported")
+	growth    = flag.Bool("growth", false, "if true, each iteration is prefixed with the ratio of its value to the value of the previous iteration")
+	digits    = flag.Bool("digits", false, "if true, each iteration is prefixed with the number of decimal digits of its value, estimated without computing huge values")
+	svgDir    = flag.String("svg", "", "if not empty, directory where the decomposition of each reported iteration is drawn as <iteration>.svg, or <seed>-<iteration>.svg in batch mode")
+	bump      = flag.String("bump", "+1", "rule bumping the base at each step: '+k' for b+k, '*k' for k*b or '^2' for b*b")
+	subtract  = flag.Int("subtract", 1, "number subtracted at each step, the sequence stopping at zero")
+	outFormat = flag.String("format", "text", "format of iterations: 'text', 'jsonl' for one JSON object per line, 'csv' or 'tsv' for spreadsheets")
+	parallel  = flag.Int("parallel", 1, "number of sequences computed concurrently in batch mode, output keeping the order of seeds")
+	stdin     = flag.Bool("stdin", false, "if true, seeds are read from stdin, one per line, instead of arguments")
+	ckpt      = flag.String("checkpoint", "", "if not empty, file where the last computed iteration is periodically saved, to be resumed with resume")
+	ckptEvery = flag.Duration("checkpoint-every", time.Minute, "period of checkpoints")
+	resume    = flag.String("resume", "", "if not empty, checkpoint file to resume the sequence from, after its last computed iteration")
+	certify   = flag.String("certificate", "", "if not empty, file where a termination certificate is written: every iteration with its ordinal, asserted to strictly decrease")
 )
 
+// one is the increment of iterations.
+var one = big.NewInt(1)
+
+// exitLimit is the exit status when a safety limit is hit before the sequence terminates.
+const exitLimit = 3
+
+// exitError is an error ending the command with a given exit status.
+// Other errors end it with status 1.
+type exitError struct {
+	status int
+	err    error
+}
+
+func (e exitError) Error() string { return e.err.Error() }
+
+func (e exitError) Unwrap() error { return e.err }
+
+// exitStatus returns the exit status of the command ended by err.
+func exitStatus(err error) int {
+	var e exitError
+	if errors.As(err, &e) {
+		return e.status
+	}
+	return 1
+}
+
+// timestampLayout is the layout of wall-clock times printed with -timestamps.
+const timestampLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+// parseSample returns a function reporting whether an iteration
+// must be printed according to the -sample flag value.
+func parseSample(s string) (func(i *big.Int) bool, error) {
+	switch s {
+	case "":
+		// report every iteration
+		return func(*big.Int) bool { return true }, nil
+
+	case "exp":
+		// report 0 and powers of two
+		return func(i *big.Int) bool {
+			return i.Sign() == 0 || uint(i.BitLen()-1) == i.TrailingZeroBits()
+		}, nil
+	}
+
+	// user-supplied schedule, by decimal iteration
+	schedule := make(map[string]bool)
+	for _, field := range strings.Split(s, ",") {
+		i, ok := new(big.Int).SetString(strings.TrimSpace(field), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid iteration %q", field)
+		}
+		if i.Sign() < 0 {
+			return nil, fmt.Errorf("invalid iteration %v: must be positive", i)
+		}
+		schedule[i.String()] = true
+	}
+	return func(i *big.Int) bool { return schedule[i.String()] }, nil
+}
+
+// readSeeds returns the seeds in r, one per line.
+// Blank lines and lines starting with '#' are ignored.
+func readSeeds(r io.Reader) ([]string, error) {
+	var seeds []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		seeds = append(seeds, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading seeds: %v", err)
+	}
+	return seeds, nil
+}
+
+// parseIteration returns the iteration in s, nil if s is empty.
+func parseIteration(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid iteration %q", s)
+	}
+	if i.Sign() < 0 {
+		return nil, fmt.Errorf("invalid iteration %v: must be positive", i)
+	}
+	return i, nil
+}
+
+// window returns a function reporting whether an iteration is in from..to.
+// Nil bounds are open.
+func window(from, to *big.Int) func(i *big.Int) bool {
+	return func(i *big.Int) bool {
+		return (from == nil || i.Cmp(from) >= 0) && (to == nil || i.Cmp(to) <= 0)
+	}
+}
+
+// parseBump returns the base rule described by the -bump flag value,
+// nil for the standard rule b -> b + 1.
+func parseBump(s string) (goodstein.BaseRule, error) {
+	if s == "+1" {
+		return nil, nil
+	}
+	if s == "^2" {
+		return goodstein.SquareBase, nil
+	}
+	if len(s) < 2 {
+		return nil, fmt.Errorf("invalid rule %q", s)
+	}
+	k, err := strconv.Atoi(s[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule %q", s)
+	}
+	switch {
+	case s[0] == '+' && k >= 1:
+		return goodstein.AddBase(k), nil
+	case s[0] == '*' && k >= 2:
+		return goodstein.MulBase(k), nil
+	default:
+		return nil, fmt.Errorf("invalid rule %q", s)
+	}
+}
+
+// step returns the decomposition following d, at iteration i,
+// in a Goodstein sequence configured by opts.
+// d must not be zero.
+func step(d decomposition.Decomposition, i *big.Int, opts []goodstein.Option) (decomposition.Decomposition, error) {
+	s, err := goodstein.NewSequenceFrom(d, i, opts...)
+	if err != nil {
+		return decomposition.Decomposition{}, err
+	}
+	s.Next() // d itself
+	if !s.Next() {
+		return decomposition.Decomposition{}, s.Err()
+	}
+	return s.Current(), nil
+}
+
+// growthFactor returns the ratio of the value of d to the value of previous as a short decimal string.
+// It is computed from approximations of the values, as floating-point numbers
+// or from their binary logarithms if they overflow, so that huge values are never computed.
+// It returns "-" if there is no previous value or if it is zero,
+// and if the ratio is too large to be approximated.
+func growthFactor(d decomposition.Decomposition, previous *decomposition.Decomposition) string {
+	if previous == nil || previous.IsZero() {
+		return "-"
+	}
+	value, prev := d.EvalFloat(64), previous.EvalFloat(64)
+	if !value.IsInf() && !prev.IsInf() {
+		return value.Quo(value, prev).Text('g', 6)
+	}
+	log, prevLog := d.Log2(), previous.Log2()
+	if log.IsInf() || prevLog.IsInf() {
+		return "-"
+	}
+	diff, _ := log.Sub(log, prevLog).Float64()
+	return strconv.FormatFloat(math.Exp2(diff), 'g', 6, 64)
+}
+
+// numDigits returns the number of decimal digits of the value of d,
+// or "-" if it is too large to be estimated.
+func numDigits(d decomposition.Decomposition) string {
+	n := d.NumDecimalDigits()
+	if n == nil {
+		return "-"
+	}
+	return n.String()
+}
+
+// reportStretch prints the stretch of iterations from..to
+// whose decompositions have the same shape, if it spans several iterations.
+// Outside of summaries, it is printed as a comment so that the output can still be resumed
+// (or compiled, in a longtable or a LaTeX document).
+func reportStretch(w io.Writer, from, to *big.Int) {
+	if to.Cmp(from) <= 0 {
+		return
+	}
+	switch {
+	case *table || *document:
+		fmt.Fprint(w, "% ")
+	case !*summary:
+		fmt.Fprint(w, "# ")
+	}
+	length := new(big.Int).Sub(to, from)
+	fmt.Fprintf(w, "stable: %v to %v (%v iterations)\n", from, to, length.Add(length, one))
+}
+
+// writeTextHeader writes the header of text output starting at iteration first
+// with decomposition d: the description of the run at the top of output files,
+// a batch having no single start, and the names of the columns.
+// Nothing is written when resuming an output, which already starts with its header.
+func writeTextHeader(w io.Writer, first *big.Int, d decomposition.Decomposition, toFile, resumed, batch bool) {
+	if resumed {
+		return
+	}
+
+	// describe the run (or not)
+	if toFile && !batch {
+		value := "-"
+		if !*noValue {
+			value = d.Eval().String()
+		}
+		writeMetadata(w, first, d.Base(), value)
+	}
+
+	// name the columns (or not)
+	if *table || *document || !*header || *summary {
+		return
+	}
+	if batch {
+		fmt.Fprint(w, "seed ")
+	}
+	if *stamps {
+		fmt.Fprint(w, "time elapsed ")
+	}
+	if *growth {
+		fmt.Fprint(w, "growth ")
+	}
+	if *digits {
+		fmt.Fprint(w, "digits ")
+	}
+	fmt.Fprintln(w, "iteration base value decomposition")
+}
+
 func main() {
+	// run subcommand (if any)
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				log.Print(err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	flag.Parse()
 
+	// exit status, set before returning so that deferred closings happen first
+	var status int
+	defer func() {
+		if status != 0 {
+			os.Exit(status)
+		}
+	}()
+
 	// check command validity
 
 	// check number of iterations
 	if *it < 0 {
 		log.Print("it must be positive")
-		os.Exit(1)
+		status = 1
+		return
 	}
 
-	// check number of arguments
-	if len(flag.Args()) != 1 {
-		log.Print("expecting one and only one argument")
-		os.Exit(1)
+	// check safety limits
+	if *maxSteps < 0 || *maxSecs < 0 {
+		log.Print("max-steps and max-seconds must be positive")
+		status = 1
+		return
 	}
 
-	// validate argument
-	n, err := strconv.ParseInt(flag.Arg(0), 10, 64)
+	// a longtable only contains iterations
+	if *table && (*summary || *appendf != "" || *stamps || *growth || *digits) {
+		log.Print("longtable is incompatible with summary, append, timestamps, growth and digits")
+		status = 1
+		return
+	}
+
+	// a LaTeX document only contains iterations too
+	if *document && (*summary || *appendf != "" || *stamps || *growth || *digits) {
+		log.Print("document is incompatible with summary, append, timestamps, growth and digits")
+		status = 1
+		return
+	}
+
+	// several seeds make a batch whose iterations are keyed by seed
+	// (a batch has no single sequence to resume, certify or typeset)
+	batch := *stdin || flag.NArg() > 1
+	if batch && (*appendf != "" || *certify != "" || *ckpt != "" || *resume != "" || *table || *document) {
+		log.Print("batch mode is incompatible with append, certificate, checkpoint, resume, longtable and document")
+		status = 1
+		return
+	}
+
+	// checkpoints are compressed like other outputs
+	if *ckpt != "" {
+		if _, err := compressed(*ckpt); err != nil {
+			log.Printf("invalid checkpoint: %v", err)
+			status = 1
+			return
+		}
+	}
+
+	// check number of workers
+	if *parallel < 1 {
+		log.Print("parallel must be at least 1")
+		status = 1
+		return
+	}
+
+	// check output format
+	switch *outFormat {
+	case "text":
+	case "jsonl", "csv", "tsv":
+		// structured formats only contain iterations and cannot be resumed
+		if *table || *document || *appendf != "" || *stable {
+			log.Printf("format %v is incompatible with longtable, document, append and stretches", *outFormat)
+			status = 1
+			return
+		}
+	default:
+		log.Printf("unknown format %q", *outFormat)
+		status = 1
+		return
+	}
+
+	// check base rule
+	rule, err := parseBump(*bump)
 	if err != nil {
-		log.Printf("invalid argument, expecting integer: %v", err)
-		os.Exit(1)
+		log.Printf("invalid bump: %v", err)
+		status = 1
+		return
 	}
-	// it must be positive too
-	if n < 0 {
-		log.Print("invalid argument, expecting positive integer")
-		os.Exit(1)
+
+	// something must be subtracted for the sequence to terminate
+	if *subtract < 1 {
+		log.Print("subtract must be positive")
+		status = 1
+		return
 	}
 
-	// compute first decomposition
-	b := 2 // initial base
-	// compute hereditary base-2 decomposition of n
-	d, err := decomposition.New(b, int(n))
+	// base rule and subtraction of the sequences, none for standard sequences
+	var opts []goodstein.Option
+	if rule != nil {
+		opts = append(opts, goodstein.Bump(rule))
+	}
+	if *subtract != 1 {
+		opts = append(opts, goodstein.Subtract(*subtract))
+	}
+
+	// check sampling schedule
+	sampled, err := parseSample(*sample)
 	if err != nil {
-		log.Printf("error while computing hereditary base-%b decomposition of %v: %v", b, n, err)
-		os.Exit(2)
+		log.Printf("invalid sample: %v", err)
+		status = 1
+		return
 	}
 
-	// print header (or not)
-	if *header {
-		fmt.Fprintln(os.Stdout, "iteration base value decomposition")
+	// check window of reported iterations
+	fromIt, err := parseIteration(*from)
+	if err != nil {
+		log.Printf("invalid from: %v", err)
+		status = 1
+		return
+	}
+	toIt, err := parseIteration(*to)
+	if err != nil {
+		log.Printf("invalid to: %v", err)
+		status = 1
+		return
+	}
+	if fromIt != nil && toIt != nil && toIt.Cmp(fromIt) < 0 {
+		log.Print("to must not be lower than from")
+		status = 1
+		return
+	}
+	inWindow := window(fromIt, toIt)
+	if fromIt != nil || toIt != nil {
+		isSampled := sampled
+		sampled = func(i *big.Int) bool { return inWindow(i) && isSampled(i) }
+	}
+
+	// directory of drawings (or not)
+	if *svgDir != "" {
+		if err := os.MkdirAll(*svgDir, 0755); err != nil {
+			log.Print(err)
+			status = 1
+			return
+		}
+	}
+
+	// write a termination certificate (or not)
+	var cert *certificate
+	if *certify != "" {
+		f, err := createOutput(*certify, false)
+		if err != nil {
+			log.Print(err)
+			status = 1
+			return
+		}
+		defer f.Close()
+		cert = newCertificate(f)
+	}
+
+	// output and first iteration
+	var (
+		out     io.Writer = os.Stdout
+		first             = new(big.Int)
+		d       decomposition.Decomposition
+		resumed bool
+	)
+
+	// write to a file (or not)
+	if *output != "" && *appendf != "" {
+		log.Print("output and append are mutually exclusive")
+		status = 1
+		return
+	}
+	if *output != "" {
+		f, err := createOutput(*output, false)
+		if err != nil {
+			log.Print(err)
+			status = 1
+			return
+		}
+		defer f.Close()
+		out = f
+	}
+
+	// resume from a previous output (or not)
+	if *appendf != "" {
+		f, last, ok, err := openAppend(*appendf)
+		if err != nil {
+			log.Print(err)
+			status = 1
+			return
+		}
+		defer f.Close()
+		out = f
+
+		// continue after the last iteration
+		if ok {
+			if last.d.IsZero() {
+				log.Printf("sequence in %v already terminated", *appendf)
+				return
+			}
+			first.Add(last.iteration, one)
+			d, err = step(last.d, last.iteration, opts)
+			if err != nil {
+				log.Print(err)
+				status = 2
+				return
+			}
+			resumed = true
+		}
 	}
 
-	// start iterations
-	for i := 0; i < *it; i++ {
-		// print result to stdout
-		var strDecomposition string
-		if *latex {
-			strDecomposition = d.LaTeX()
-		} else {
-			strDecomposition = d.String()
+	// resume from a checkpoint (or not)
+	if *resume != "" {
+		if *appendf != "" {
+			log.Print("append and resume are mutually exclusive")
+			status = 1
+			return
+		}
+		c, err := readCheckpoint(*resume)
+		if err != nil {
+			log.Print(err)
+			status = 1
+			return
+		}
+
+		// next iterations must be computed with the same rules
+		if c.bump != *bump || c.subtract != *subtract {
+			log.Printf("checkpoint %v was computed with bump %v and subtract %v", *resume, c.bump, c.subtract)
+			status = 1
+			return
 		}
-		fmt.Fprintf(os.Stdout, "%v %v %v %q\n", i, b, d.Eval(), strDecomposition)
 
-		// if decomposition is zero, stop
-		if d.IsZero() {
-			os.Exit(0)
+		// continue after the last computed iteration
+		if c.d.IsZero() {
+			log.Printf("sequence in %v already terminated", *resume)
+			return
+		}
+		first.Add(c.iteration, one)
+		d, err = step(c.d, c.iteration, opts)
+		if err != nil {
+			log.Print(err)
+			status = 2
+			return
 		}
+		resumed = true
+	}
+
+	// check number of arguments
+	switch {
+	case resumed && (flag.NArg() != 0 || *stdin):
+		log.Print("expecting no argument when resuming")
+		status = 1
+		return
+	case !resumed && *stdin && flag.NArg() != 0:
+		log.Print("expecting no argument when reading seeds from stdin")
+		status = 1
+		return
+	case !resumed && !*stdin && flag.NArg() == 0:
+		log.Print("expecting at least one argument")
+		status = 1
+		return
+	}
+
+	// first state of each sequence
+	var (
+		seeds  []*big.Int
+		states []state
+	)
+	if resumed {
+		states = append(states, state{iteration: first, d: d})
+	} else {
+		// seeds are the arguments or the lines of stdin
+		args := flag.Args()
+		if *stdin {
+			args, err = readSeeds(os.Stdin)
+			if err != nil {
+				log.Print(err)
+				status = 1
+				return
+			}
+		}
+
+		for _, arg := range args {
+			// validate argument, which may not fit in an int
+			n, ok := new(big.Int).SetString(arg, 10)
+			if !ok {
+				log.Printf("invalid argument, expecting integer: %q", arg)
+				status = 1
+				return
+			}
+			// it must be positive too
+			if n.Sign() < 0 {
+				log.Print("invalid argument, expecting positive integer")
+				status = 1
+				return
+			}
+
+			// compute first decomposition
+			b := 2 // initial base
+			// compute hereditary base-2 decomposition of n
+			d, err := decomposition.NewBig(b, n)
+			if err != nil {
+				log.Printf("error while computing hereditary base-%v decomposition of %v: %v", b, n, err)
+				status = 2
+				return
+			}
+			seeds = append(seeds, n)
+			states = append(states, state{iteration: new(big.Int), d: d})
+		}
+	}
+
+	// describe the run and name the columns at the top of text output
+	if *outFormat == "text" {
+		writeTextHeader(out, first, states[0].d, *output != "" || *appendf != "", resumed, batch)
+	}
+
+	// structured output (or not)
+	var rows rowWriter
+	if *outFormat != "text" {
+		rows = newRowWriter(out, *outFormat, batch, *stamps, *growth)
+		if *header && !*summary {
+			if err := rows.header(); err != nil {
+				log.Print(err)
+				status = 1
+				return
+			}
+		}
+	}
+
+	// print header (or not)
+	if *document {
+		writeDocumentBegin(out)
+	}
+	if *table {
+		writeLongtableBegin(out)
+	} else if *document {
+		writeAlignBegin(out)
+	}
+
+	// run every sequence
+	r := runner{
+		out:      out,
+		rows:     rows,
+		opts:     opts,
+		sampled:  sampled,
+		inWindow: inWindow,
+		from:     fromIt,
+		to:       toIt,
+		cert:     cert,
+	}
+	if !batch {
+		// a single sequence is not keyed by seed
+		seeds = nil
+	}
+	ok, err := r.runAll(states, seeds, *parallel)
+	if err != nil {
+		log.Print(err)
+		status = exitStatus(err)
+		return
+	}
+	if !ok {
+		// a safety limit is not a normal end
+		status = exitLimit
+	}
+
+	// flush structured output (or not)
+	if rows != nil {
+		if err := rows.flush(); err != nil {
+			log.Print(err)
+			status = 1
+			return
+		}
+	}
+
+	// end the longtable (or not)
+	if *table {
+		writeLongtableEnd(out)
+	} else if *document {
+		writeAlignEnd(out)
+	}
 
-		// increment base and remove one
-		b++ // for reporting only
-		d = d.IncrementBase().Decrement()
+	// end the document (or not)
+	if *document {
+		writeDocumentEnd(out)
 	}
 }
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+func TestGrowthFactor(t *testing.T) {
+	for _, g := range []struct {
+		d, previous string // no previous iteration if empty
+		expected    string
+	}{
+		{"2 + 1", "", "-"},
+		{"0", "0", "-"},
+		{"2 + 1", "2", "1.5"},
+		{"0", "1", "0"},
+		{"2 * 3 ^ (2) + 2", "3 ^ (2) + 2 * 3", "1.33333"},
+		// values overflowing floating-point numbers
+		{"2 * 3 ^ (3 ^ (3 ^ (3)))", "3 ^ (3 ^ (3 ^ (3)))", "2"},
+		{"3 ^ (3 ^ (3 ^ (3)))", "3 ^ (3 ^ (3 ^ (3))) + 3", "1"},
+		// ratios too large to be approximated
+		{"2 ^ (2 ^ (2 ^ (2 ^ (2 ^ (2 ^ (2) + 1)))))", "2", "-"},
+	} {
+		d, err := decomposition.Parse(g.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var previous *decomposition.Decomposition
+		if g.previous != "" {
+			p, err := decomposition.Parse(g.previous)
+			if err != nil {
+				t.Fatal(err)
+			}
+			previous = &p
+		}
+		if got := growthFactor(d, previous); got != g.expected {
+			t.Errorf("%v / %v: got %v, expecting %v", g.d, g.previous, got, g.expected)
+		}
+	}
+}
+
+func TestAppendHeader(t *testing.T) {
+	defer func(n int) { *it = n }(*it)
+	*it = 2
+
+	name := filepath.Join(t.TempDir(), "output")
+	all := func(*big.Int) bool { return true }
+	for k := 0; k < 2; k++ {
+		f, last, resumed, err := openAppend(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, d := new(big.Int), last.d
+		if resumed {
+			first.Add(last.iteration, one)
+			if d, err = step(last.d, last.iteration, nil); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			d, _ = decomposition.New(2, 4)
+		}
+		writeTextHeader(f, first, d, true, resumed, false)
+		rn := runner{out: f, sampled: all, inWindow: all}
+		if _, err := rn.run(first, d); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(data)
+	if n := strings.Count(output, "# goodstein version:"); n != 1 {
+		t.Errorf("got %v descriptions of the run, expecting 1:\n%v", n, output)
+	}
+	if n := strings.Count(output, "iteration base value decomposition\n"); n != 1 {
+		t.Errorf("got %v headers, expecting 1:\n%v", n, output)
+	}
+	if !strings.HasSuffix(output, "\n3 5 60 \"2 * 5 ^ (2) + 2 * 5\"\n") {
+		t.Errorf("got output not ending with iteration 3:\n%v", output)
+	}
+}
+
+func TestParseSample(t *testing.T) {
+	for _, g := range []struct {
+		sample  string
+		sampled []int64 // among 0 to 10, nil for an error
+	}{
+		{"", []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{"exp", []int64{0, 1, 2, 4, 8}},
+		{"3", []int64{3}},
+		{"0,5,10", []int64{0, 5, 10}},
+		{"5,0", []int64{0, 5}},
+		// duplicates are harmless
+		{"2,2,7", []int64{2, 7}},
+		// so is whitespace around iterations
+		{" 1 , 9,\t4 ", []int64{1, 4, 9}},
+		{"007", []int64{7}},
+		{"100000000000000000000000000000", []int64{}},
+		// invalid iterations
+		{"-1", nil},
+		{"1,-2", nil},
+		{"1,,2", nil},
+		{"1;2", nil},
+		{"x", nil},
+		{"1.5", nil},
+		{"EXP", nil},
+	} {
+		sampled, err := parseSample(g.sample)
+		if g.sampled == nil {
+			if err == nil {
+				t.Errorf("%q: expecting an error", g.sample)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", g.sample, err)
+			continue
+		}
+		var got []int64
+		for i := int64(0); i <= 10; i++ {
+			if sampled(big.NewInt(i)) {
+				got = append(got, i)
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(g.sampled) {
+			t.Errorf("%q: got %v, expecting %v", g.sample, got, g.sampled)
+		}
+	}
+
+	// exp samples powers of two beyond int64
+	sampled, _ := parseSample("exp")
+	p := new(big.Int).Lsh(big.NewInt(1), 100)
+	if !sampled(p) || sampled(p.Add(p, one)) {
+		t.Errorf("exp: wrong sampling of 2^100 or 2^100 + 1")
+	}
+}
+
+func TestParseIteration(t *testing.T) {
+	for _, g := range []struct {
+		s        string
+		expected string // "nil" for no iteration, "" for an error
+	}{
+		{"", "nil"},
+		{"0", "0"},
+		{"42", "42"},
+		{"007", "7"},
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+		{"-1", ""},
+		{" 1", ""},
+		{"1e3", ""},
+		{"x", ""},
+	} {
+		i, err := parseIteration(g.s)
+		switch {
+		case g.expected == "" && err == nil:
+			t.Errorf("%q: got %v, expecting an error", g.s, i)
+		case g.expected == "":
+		case err != nil:
+			t.Errorf("%q: unexpected error %v", g.s, err)
+		case g.expected == "nil" && i != nil:
+			t.Errorf("%q: got %v, expecting nil", g.s, i)
+		case g.expected != "nil" && (i == nil || i.String() != g.expected):
+			t.Errorf("%q: got %v, expecting %v", g.s, i, g.expected)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	for _, g := range []struct {
+		from, to int64 // -1 if not set
+		inside   []int64
+	}{
+		{-1, -1, []int64{0, 1, 2, 3, 4, 5}},
+		{2, -1, []int64{2, 3, 4, 5}},
+		{-1, 2, []int64{0, 1, 2}},
+		{1, 3, []int64{1, 2, 3}},
+		{3, 3, []int64{3}},
+		{0, 0, []int64{0}},
+		{4, 2, nil},
+	} {
+		var from, to *big.Int
+		if g.from >= 0 {
+			from = big.NewInt(g.from)
+		}
+		if g.to >= 0 {
+			to = big.NewInt(g.to)
+		}
+		inWindow := window(from, to)
+		var got []int64
+		for i := int64(0); i <= 5; i++ {
+			if inWindow(big.NewInt(i)) {
+				got = append(got, i)
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(g.inside) {
+			t.Errorf("from %v to %v: got %v, expecting %v", g.from, g.to, got, g.inside)
+		}
+	}
+}
+
+func TestParseBump(t *testing.T) {
+	for _, g := range []struct {
+		bump string
+		next int // base following 5, 0 for an error, -1 for the standard rule (nil)
+	}{
+		{"+1", -1},
+		{"+2", 7},
+		{"+10", 15},
+		{"*2", 10},
+		{"*3", 15},
+		{"^2", 25},
+		// invalid rules
+		{"", 0},
+		{"+", 0},
+		{"+0", 0},
+		{"+-1", 0},
+		{"*1", 0},
+		{"*0", 0},
+		{"^3", 0},
+		{"^1", 0},
+		{"-1", 0},
+		{"+x", 0},
+		{"2", 0},
+		{" +1", 0},
+	} {
+		rule, err := parseBump(g.bump)
+		switch {
+		case g.next == 0:
+			if err == nil {
+				t.Errorf("%q: expecting an error", g.bump)
+			}
+		case err != nil:
+			t.Errorf("%q: unexpected error %v", g.bump, err)
+		case g.next < 0:
+			if rule != nil {
+				t.Errorf("%q: expecting the standard rule", g.bump)
+			}
+		case rule == nil:
+			t.Errorf("%q: got the standard rule, expecting base %v after 5", g.bump, g.next)
+		case rule(0, 5) != g.next:
+			t.Errorf("%q: got base %v after 5, expecting %v", g.bump, rule(0, 5), g.next)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package ordinal
+
+import "math"
+
+// Descent is a Goodstein-style descent process over ordinals in Veblen normal form,
+// which generalizes Goodstein sequences beyond epsilon_0.
+// At each step, the current ordinal is replaced by the element of its fundamental sequence
+// at the current base n until it is a successor, one is removed
+// and the base is incremented.
+// The process terminates since ordinals strictly decrease, after H_a(n) - n steps
+// where H is the Hardy hierarchy, a the initial ordinal and n the initial base.
+//
+// Starting from the ordinal of the hereditary base-b decomposition of a seed with base b + 1,
+// the descent follows the ordinals of the Goodstein sequence of the seed.
+// Like bufio.Scanner, successive calls to Next advance the descent by one step:
+//
+//	d := NewDescent(Omega.Add(Nat(1)).Veblen(), 3)
+//	for d.Next() {
+//		fmt.Println(d.Step(), d.Base(), d.Ordinal())
+//	}
+type Descent struct {
+	a    Veblen
+	base int
+	step int
+}
+
+// NewDescent returns a descent from the ordinal a with initial base n.
+// n must be non negative.
+func NewDescent(a Veblen, n int) *Descent {
+	if n < 0 {
+		panic("ordinal: negative base")
+	}
+	return &Descent{a: a, base: n}
+}
+
+// Next advances the descent to its next step.
+// It returns false if the ordinal is already zero,
+// or if the base cannot be incremented any more.
+func (d *Descent) Next() bool {
+	if d.a.IsZero() || d.base == math.MaxInt {
+		return false
+	}
+	for d.a.IsLimit() {
+		d.a = d.a.Fundamental(d.base)
+	}
+	d.a = d.a.predecessor()
+	d.base++
+	d.step++
+	return true
+}
+
+// Step returns the number of steps so far.
+func (d *Descent) Step() int {
+	return d.step
+}
+
+// Base returns the current base.
+func (d *Descent) Base() int {
+	return d.base
+}
+
+// Ordinal returns the current ordinal.
+func (d *Descent) Ordinal() Veblen {
+	return d.a
+}
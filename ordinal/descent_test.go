@@ -0,0 +1,61 @@
+package ordinal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleDescent() {
+	// ordinals of the Goodstein sequence of 3, i.e. 2 + 1 in base 2
+	d := NewDescent(Omega.Add(Nat(1)).Veblen(), 3)
+	for d.Next() {
+		fmt.Println(d.Step(), d.Base(), d.Ordinal())
+	}
+	// Output:
+	// 1 4 ω
+	// 2 5 3
+	// 3 6 2
+	// 4 7 1
+	// 5 8 0
+}
+
+func TestDescentHardy(t *testing.T) {
+	// the number of steps is H_a(n) - n
+	for _, a := range []Ordinal{Nat(0), Nat(3), Omega, Omega.Add(Omega).Add(Nat(1)), OmegaPow(Nat(2))} {
+		for n := 1; n < 4; n++ {
+			h, err := Hardy(a, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := NewDescent(a.Veblen(), n)
+			for d.Next() {
+			}
+			if int64(d.Step()) != h.Int64()-int64(n) {
+				t.Errorf("descent from %v at %v: %v steps, expecting %v", a, n, d.Step(), h.Int64()-int64(n))
+			}
+		}
+	}
+}
+
+func TestDescentDecreases(t *testing.T) {
+	// beyond epsilon_0
+	one := Nat(1).Veblen()
+	for _, g := range []struct {
+		a Veblen
+		n int
+	}{
+		{epsilon0, 3},
+		{epsilon0.Add(one), 2},
+		{Phi(one, one), 2},
+		{Phi(Nat(2).Veblen(), Veblen{}), 1},
+	} {
+		d := NewDescent(g.a, g.n)
+		previous := g.a
+		for i := 0; i < 20 && d.Next(); i++ {
+			if d.Ordinal().Cmp(previous) >= 0 {
+				t.Errorf("step %v from %v: %v is not lower than %v", d.Step(), g.a, d.Ordinal(), previous)
+			}
+			previous = d.Ordinal()
+		}
+	}
+}
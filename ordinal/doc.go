@@ -0,0 +1,19 @@
+/*
+Package ordinal provides ordinals below epsilon_0 in Cantor normal form,
+and below Gamma_0 in Veblen normal form.
+
+Any ordinal alpha below epsilon_0 is uniquely written
+
+	alpha = omega^{alpha_1} c_1 + ... + omega^{alpha_k} c_k
+
+where alpha_1 > ... > alpha_k are themselves in Cantor normal form
+and c_1, ..., c_k are positive integers.
+Ordinals are built with Nat, OmegaPow and Term, and summed with Add or Sum,
+which normalize the result into Cantor normal form.
+These ordinals witness the termination of Goodstein sequences.
+
+Beyond epsilon_0, ordinals below Gamma_0 are written in Veblen normal form,
+as sums of phi(a, b) where phi is the binary Veblen function, with the Veblen type.
+Descent generalizes Goodstein sequences to these ordinals.
+*/
+package ordinal
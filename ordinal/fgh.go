@@ -0,0 +1,73 @@
+package ordinal
+
+import "math/big"
+
+// maxBits is the maximum bit length of values computed
+// by the fast-growing hierarchy.
+const maxBits = 1 << 24
+
+// FastGrowing returns f_a(n) where f is the fast-growing hierarchy:
+//   - f_0(n) = n + 1
+//   - f_(a+1)(n) = f_a^n(n), i.e. f_a iterated n times
+//   - f_l(n) = f_(l[n])(n) if l is a limit ordinal.
+//
+// f_1(n) = 2n and f_2(n) = 2^n n are computed directly.
+// Values grow extremely fast so only small ordinals and small n are feasible:
+// it returns ErrInfeasible when a value would have more than 2^24 bits
+// or when the computation would take too long.
+// n must be non negative.
+func FastGrowing(a Ordinal, n int) (*big.Int, error) {
+	if n < 0 {
+		panic("ordinal: negative argument")
+	}
+	steps := 0
+	return fastGrowing(a, big.NewInt(int64(n)), &steps)
+}
+
+// fastGrowing returns f_a(x).
+// steps counts the number of evaluations so far.
+// x is left unchanged.
+func fastGrowing(a Ordinal, x *big.Int, steps *int) (*big.Int, error) {
+	if *steps++; *steps > maxSteps {
+		return nil, ErrInfeasible
+	}
+
+	switch {
+	case a.IsZero():
+		// f_0(x) = x + 1
+		return new(big.Int).Add(x, big.NewInt(1)), nil
+
+	case a.Cmp(Nat(1)) == 0:
+		// f_1(x) = 2x
+		return new(big.Int).Lsh(x, 1), nil
+
+	case a.Cmp(Nat(2)) == 0:
+		// f_2(x) = 2^x x
+		if !x.IsInt64() || x.Int64()+int64(x.BitLen()) > maxBits {
+			return nil, ErrInfeasible
+		}
+		return new(big.Int).Lsh(x, uint(x.Int64())), nil
+	}
+
+	// other cases need x as an index
+	if !x.IsInt64() || x.Int64() > maxSteps {
+		return nil, ErrInfeasible
+	}
+	n := int(x.Int64())
+
+	if a.IsLimit() {
+		// f_l(x) = f_(l[x])(x)
+		return fastGrowing(a.Fundamental(n), x, steps)
+	}
+
+	// f_(a+1)(x) = f_a^x(x)
+	pred := a.predecessor()
+	y := x
+	for i := 0; i < n; i++ {
+		var err error
+		if y, err = fastGrowing(pred, y, steps); err != nil {
+			return nil, err
+		}
+	}
+	return y, nil
+}
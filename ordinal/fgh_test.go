@@ -0,0 +1,37 @@
+package ordinal
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFastGrowing(t *testing.T) {
+	for _, g := range []struct {
+		a        Ordinal
+		n        int
+		expected int64
+	}{
+		{Nat(0), 3, 4},
+		{Nat(1), 3, 6},
+		{Nat(2), 3, 24},
+		{Nat(3), 2, 2048},         // f_2(f_2(2)) = f_2(8)
+		{Omega, 2, 8},             // f_2(2)
+		{Omega.Add(Nat(1)), 1, 2}, // f_omega(1) = f_1(1)
+	} {
+		f, err := FastGrowing(g.a, g.n)
+		if err != nil {
+			t.Errorf("f_%v(%v): unexpected error %v", g.a, g.n, err)
+			continue
+		}
+		if f.Int64() != g.expected {
+			t.Errorf("f_%v(%v) = %v, expecting %v", g.a, g.n, f, g.expected)
+		}
+	}
+}
+
+func TestFastGrowingInfeasible(t *testing.T) {
+	// f_omega(3) = f_3(3) = f_2(f_2(24)) has about 400 million bits
+	if _, err := FastGrowing(Omega, 3); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("expecting ErrInfeasible, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package ordinal
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ErrInfeasible is returned when a value is far too large to be computed.
+var ErrInfeasible = errors.New("ordinal: value is infeasible to compute")
+
+// maxSteps is the maximum number of limit steps
+// when evaluating a function of the hierarchies.
+const maxSteps = 1 << 24
+
+// predecessor returns the ordinal a - 1.
+// a must be a successor ordinal.
+func (a Ordinal) predecessor() Ordinal {
+	terms := make([]term, len(a.terms))
+	copy(terms, a.terms)
+	if terms[0].coeff--; terms[0].coeff == 0 {
+		terms = terms[1:]
+	}
+	return Ordinal{terms}
+}
+
+// Fundamental returns the n-th element a[n] of the standard
+// fundamental sequence of the limit ordinal a:
+//   - (g + omega ^ (b+1))[n] = g + omega ^ b * n
+//   - (g + omega ^ l)[n] = g + omega ^ (l[n]) if l is a limit ordinal.
+//
+// a must be a limit ordinal and n must be non negative.
+func (a Ordinal) Fundamental(n int) Ordinal {
+	if !a.IsLimit() {
+		panic("ordinal: fundamental sequence of a non limit ordinal")
+	}
+
+	// split a into g + omega ^ e
+	least := a.terms[0]
+	g := Ordinal{a.terms[1:]}
+	if least.coeff > 1 {
+		g = Ordinal{append([]term{term{least.exponent, least.coeff - 1}}, a.terms[1:]...)}
+	}
+	e := least.exponent
+
+	if e.IsSuccessor() {
+		if n == 0 {
+			return g
+		}
+		return g.Add(Ordinal{[]term{term{e.predecessor(), n}}})
+	}
+	return g.Add(OmegaPow(e.Fundamental(n)))
+}
+
+// finite returns the finite part of a, i.e. its constant term.
+func (a Ordinal) finite() int {
+	if a.IsSuccessor() {
+		return a.terms[0].coeff
+	}
+	return 0
+}
+
+// infinite returns a without its finite part.
+func (a Ordinal) infinite() Ordinal {
+	if a.IsSuccessor() {
+		return Ordinal{a.terms[1:]}
+	}
+	return a
+}
+
+// Hardy returns H_a(n) where H is the Hardy hierarchy:
+//   - H_0(n) = n
+//   - H_(a+1)(n) = H_a(n+1)
+//   - H_l(n) = H_(l[n])(n) if l is a limit ordinal.
+//
+// Values grow extremely fast so only small ordinals and small n are feasible:
+// it returns ErrInfeasible when the computation would take too long.
+// n must be non negative.
+func Hardy(a Ordinal, n int) (*big.Int, error) {
+	if n < 0 {
+		panic("ordinal: negative argument")
+	}
+
+	for steps := 0; ; steps++ {
+		// H_(g+k)(n) = H_g(n+k)
+		k := a.finite()
+		if n > math.MaxInt-k {
+			return nil, ErrInfeasible
+		}
+		n += k
+		a = a.infinite()
+
+		if a.IsZero() {
+			return big.NewInt(int64(n)), nil
+		}
+		if steps == maxSteps {
+			return nil, ErrInfeasible
+		}
+		a = a.Fundamental(n)
+	}
+}
@@ -0,0 +1,36 @@
+package ordinal
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHardy(t *testing.T) {
+	for n := 0; n < 10; n++ {
+		for _, g := range []struct {
+			a        Ordinal
+			expected int64
+		}{
+			{Nat(0), int64(n)},
+			{Nat(5), int64(n + 5)},
+			{Omega, int64(2 * n)},
+			{Omega.Add(Omega), int64(4 * n)},
+			{OmegaPow(Nat(2)), int64(n) << uint(n)},
+		} {
+			h, err := Hardy(g.a, n)
+			if err != nil {
+				t.Errorf("H_%v(%v): unexpected error %v", g.a, n, err)
+				continue
+			}
+			if h.Int64() != g.expected {
+				t.Errorf("H_%v(%v) = %v, expecting %v", g.a, n, h, g.expected)
+			}
+		}
+	}
+}
+
+func TestHardyInfeasible(t *testing.T) {
+	if _, err := Hardy(OmegaPow(OmegaPow(Omega)), 3); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("expecting ErrInfeasible, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package ordinal
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Ordinal is an ordinal below epsilon_0 in Cantor normal form.
+// The default value of Ordinal is zero.
+type Ordinal struct {
+	// order of the terms matter:
+	// they are sorted from least to most significant
+	terms []term
+}
+
+// term is an expression of the form 'omega ^ exponent * coeff'
+// where coeff is a positive integer.
+type term struct {
+	exponent Ordinal
+	coeff    int
+}
+
+// Nat returns the finite ordinal n.
+// n must be non negative.
+func Nat(n int) Ordinal {
+	if n < 0 {
+		panic("ordinal: negative natural number")
+	}
+	if n == 0 {
+		return Ordinal{}
+	}
+	return Ordinal{[]term{term{coeff: n}}}
+}
+
+// Omega is the first infinite ordinal.
+var Omega = OmegaPow(Nat(1))
+
+// OmegaPow returns omega ^ a.
+func OmegaPow(a Ordinal) Ordinal {
+	return Ordinal{[]term{term{exponent: a, coeff: 1}}}
+}
+
+// Term returns omega ^ exponent * coeff.
+// coeff must be non negative.
+func Term(exponent Ordinal, coeff int) Ordinal {
+	return OmegaPow(exponent).MulNat(coeff)
+}
+
+// Sum returns the ordinal sum of the ordinals, from left to right.
+// Terms are given in any order and the sum is normalized into Cantor normal form:
+// lower terms followed by greater ones are absorbed, e.g. 1 + ω is ω.
+func Sum(ordinals ...Ordinal) Ordinal {
+	var sum Ordinal
+	for _, a := range ordinals {
+		sum = sum.Add(a)
+	}
+	return sum
+}
+
+// IsZero returns true if the ordinal is zero.
+func (a Ordinal) IsZero() bool {
+	return len(a.terms) == 0
+}
+
+// IsSuccessor returns true if the ordinal is a successor ordinal, i.e. a + 1 for some a.
+func (a Ordinal) IsSuccessor() bool {
+	return !a.IsZero() && a.terms[0].exponent.IsZero()
+}
+
+// IsLimit returns true if the ordinal is a limit ordinal.
+func (a Ordinal) IsLimit() bool {
+	return !a.IsZero() && !a.IsSuccessor()
+}
+
+// Add returns the ordinal sum a + b.
+// Ordinal addition is not commutative: 1 + omega is omega.
+func (a Ordinal) Add(b Ordinal) Ordinal {
+	if b.IsZero() {
+		return a
+	}
+
+	// terms of a lower than the leading term of b are absorbed
+	lead := b.terms[len(b.terms)-1]
+	i := 0
+	for i < len(a.terms) && a.terms[i].exponent.Cmp(lead.exponent) < 0 {
+		i++
+	}
+
+	sum := make([]term, 0, len(b.terms)+len(a.terms)-i)
+	sum = append(sum, b.terms...)
+	if i < len(a.terms) && a.terms[i].exponent.Cmp(lead.exponent) == 0 {
+		// same exponent: coefficients are added
+		sum[len(sum)-1].coeff += a.terms[i].coeff
+		i++
+	}
+	sum = append(sum, a.terms[i:]...)
+	return Ordinal{sum}
+}
+
+// MulNat returns the ordinal product a * n of a by a natural number.
+// Only the leading term of a is multiplied, e.g. (ω + 1) * 2 is ω * 2 + 1.
+// n must be non negative.
+func (a Ordinal) MulNat(n int) Ordinal {
+	if n < 0 {
+		panic("ordinal: negative natural number")
+	}
+	if n == 0 || a.IsZero() {
+		return Ordinal{}
+	}
+
+	terms := make([]term, len(a.terms))
+	copy(terms, a.terms)
+	terms[len(terms)-1].coeff *= n
+	return Ordinal{terms}
+}
+
+// Cmp compares a and b.
+// It returns -1, 0 or +1 depending on whether a is lower, equal or greater than b.
+func (a Ordinal) Cmp(b Ordinal) int {
+	i, j := len(a.terms)-1, len(b.terms)-1
+	for ; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := a.terms[i].exponent.Cmp(b.terms[j].exponent); c != 0 {
+			return c
+		}
+		switch {
+		case a.terms[i].coeff < b.terms[j].coeff:
+			return -1
+		case a.terms[i].coeff > b.terms[j].coeff:
+			return 1
+		}
+	}
+
+	// one of them is a prefix of the other one
+	switch {
+	case i >= 0:
+		return 1
+	case j >= 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// String returns a human readable ordinal
+// where most significant terms lie on the left
+// and least significant ones on the right, e.g. "ω ^ (ω) * 2 + 3".
+func (a Ordinal) String() string {
+	if a.IsZero() {
+		return "0"
+	}
+
+	strTerms := make([]string, len(a.terms))
+	for i, t := range a.terms {
+		strTerms[len(a.terms)-1-i] = t.String()
+	}
+	return strings.Join(strTerms, " + ")
+}
+
+// LaTeX returns the LaTeX code of the ordinal, e.g. `\omega^{\omega} \cdot 2 + 3`.
+// It must be used in math mode.
+func (a Ordinal) LaTeX() string {
+	if a.IsZero() {
+		return "0"
+	}
+
+	latexTerms := make([]string, len(a.terms))
+	for i, t := range a.terms {
+		latexTerms[len(a.terms)-1-i] = t.latex()
+	}
+	return strings.Join(latexTerms, " + ")
+}
+
+// latex returns the LaTeX code of a term.
+func (t term) latex() string {
+	strCoeff := strconv.Itoa(t.coeff)
+
+	var power string
+	switch {
+	case t.exponent.IsZero():
+		return strCoeff
+	case t.exponent.Cmp(Nat(1)) == 0:
+		power = `\omega`
+	default:
+		power = `\omega^{` + t.exponent.LaTeX() + `}`
+	}
+
+	if t.coeff == 1 {
+		return power
+	}
+	return power + ` \cdot ` + strCoeff
+}
+
+// String returns a human readable term.
+func (t term) String() string {
+	strCoeff := strconv.Itoa(t.coeff)
+
+	// omega ^ exponent part
+	var power string
+	switch {
+	case t.exponent.IsZero():
+		// omega ^ 0 is one, so the term is equal to its coeff
+		return strCoeff
+	case t.exponent.Cmp(Nat(1)) == 0:
+		power = "ω"
+	default:
+		power = "ω ^ (" + t.exponent.String() + ")"
+	}
+
+	if t.coeff == 1 {
+		return power
+	}
+	return power + " * " + strCoeff
+}
@@ -0,0 +1,123 @@
+package ordinal
+
+import "testing"
+
+func TestString(t *testing.T) {
+	for _, g := range []struct {
+		a        Ordinal
+		expected string
+	}{
+		{Ordinal{}, "0"},
+		{Nat(3), "3"},
+		{Omega, "ω"},
+		{Omega.Add(Omega).Add(Nat(3)), "ω * 2 + 3"},
+		{OmegaPow(Omega).Add(Omega), "ω ^ (ω) + ω"},
+		{Nat(3).Add(Omega), "ω"},
+	} {
+		if s := g.a.String(); s != g.expected {
+			t.Errorf("got %q, expecting %q", s, g.expected)
+		}
+	}
+}
+
+func TestCmp(t *testing.T) {
+	// increasing ordinals
+	ordinals := []Ordinal{
+		Nat(0),
+		Nat(1),
+		Nat(2),
+		Omega,
+		Omega.Add(Nat(1)),
+		Omega.Add(Omega),
+		OmegaPow(Nat(2)),
+		OmegaPow(Omega),
+		OmegaPow(Omega).Add(Nat(1)),
+		OmegaPow(OmegaPow(Omega)),
+	}
+	for i, a := range ordinals {
+		for j, b := range ordinals {
+			expected := 0
+			switch {
+			case i < j:
+				expected = -1
+			case i > j:
+				expected = 1
+			}
+			if c := a.Cmp(b); c != expected {
+				t.Errorf("comparing %v and %v: got %v, expecting %v", a, b, c, expected)
+			}
+		}
+	}
+}
+
+func TestFundamental(t *testing.T) {
+	for _, g := range []struct {
+		a        Ordinal
+		n        int
+		expected string
+	}{
+		{Omega, 3, "3"},
+		{Omega.Add(Omega), 3, "ω + 3"},
+		{OmegaPow(Nat(2)), 3, "ω * 3"},
+		{OmegaPow(Omega), 3, "ω ^ (3)"},
+		{OmegaPow(Omega).Add(Omega), 2, "ω ^ (ω) + 2"},
+	} {
+		if s := g.a.Fundamental(g.n).String(); s != g.expected {
+			t.Errorf("%v[%v] = %q, expecting %q", g.a, g.n, s, g.expected)
+		}
+	}
+}
+
+func TestMulNat(t *testing.T) {
+	for _, g := range []struct {
+		a        Ordinal
+		n        int
+		expected string
+	}{
+		{Nat(3), 0, "0"},
+		{Nat(3), 2, "6"},
+		{Omega, 3, "ω * 3"},
+		{Omega.Add(Nat(1)), 2, "ω * 2 + 1"},
+		{OmegaPow(Omega).Add(Omega), 2, "ω ^ (ω) * 2 + ω"},
+	} {
+		if s := g.a.MulNat(g.n).String(); s != g.expected {
+			t.Errorf("%v * %v: got %q, expecting %q", g.a, g.n, s, g.expected)
+		}
+	}
+}
+
+func TestLaTeX(t *testing.T) {
+	for _, g := range []struct {
+		a        Ordinal
+		expected string
+	}{
+		{Ordinal{}, "0"},
+		{Nat(3), "3"},
+		{Omega, `\omega`},
+		{Term(Nat(1), 2).Add(Nat(3)), `\omega \cdot 2 + 3`},
+		{Term(Omega, 2).Add(Nat(3)), `\omega^{\omega} \cdot 2 + 3`},
+		{OmegaPow(OmegaPow(Omega)), `\omega^{\omega^{\omega}}`},
+	} {
+		if s := g.a.LaTeX(); s != g.expected {
+			t.Errorf("got %q, expecting %q", s, g.expected)
+		}
+	}
+}
+
+func TestSum(t *testing.T) {
+	for _, g := range []struct {
+		a        Ordinal
+		expected string
+	}{
+		{Sum(), "0"},
+		{Sum(Nat(1), Nat(2)), "3"},
+		{Sum(Nat(1), Omega), "ω"},
+		{Sum(Omega, Nat(1), Omega), "ω * 2"},
+		{Sum(Term(Nat(2), 3), Omega, Term(Nat(2), 1), Nat(4)), "ω ^ (2) * 4 + 4"},
+		{Sum(Term(Nat(0), 0), Term(Omega, 1)), "ω ^ (ω)"},
+	} {
+		if s := g.a.String(); s != g.expected {
+			t.Errorf("got %q, expecting %q", s, g.expected)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package ordinal
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Veblen is an ordinal below Gamma_0 in Veblen normal form:
+//
+//	alpha = phi(a_1, b_1) c_1 + ... + phi(a_k, b_k) c_k
+//
+// where phi is the binary Veblen function, phi(0, b) being omega ^ b
+// and phi(a, .) enumerating the common fixed points of all phi(a', .) with a' < a,
+// so that phi(1, 0) is epsilon_0.
+// Terms are sorted in decreasing order, c_1, ..., c_k are positive integers
+// and each phi(a, b) is normal, i.e. b < phi(a, b).
+// Veblen ordinals are built with Phi and Ordinal.Veblen, and summed with Add.
+// The default value of Veblen is zero.
+type Veblen struct {
+	// order of the terms matter:
+	// they are sorted from least to most significant
+	terms []vterm
+}
+
+// vterm is an expression of the form 'phi(a, b) * coeff'
+// where coeff is a positive integer.
+type vterm struct {
+	a, b  Veblen
+	coeff int
+}
+
+// Phi returns phi(a, b), which is b itself if b is already a fixed point of phi(a, .),
+// e.g. phi(0, epsilon_0) is epsilon_0.
+func Phi(a, b Veblen) Veblen {
+	if len(b.terms) == 1 && b.terms[0].coeff == 1 && b.terms[0].a.Cmp(a) > 0 {
+		return b
+	}
+	return Veblen{[]vterm{{a: a, b: b, coeff: 1}}}
+}
+
+// Veblen returns the ordinal in Veblen normal form,
+// each term omega ^ e * c becoming phi(0, e) * c.
+func (a Ordinal) Veblen() Veblen {
+	terms := make([]vterm, len(a.terms))
+	for i, t := range a.terms {
+		terms[i] = vterm{b: t.exponent.Veblen(), coeff: t.coeff}
+	}
+	return Veblen{terms}
+}
+
+// vone is phi(0, 0), i.e. 1.
+var vone = Veblen{[]vterm{{coeff: 1}}}
+
+// IsZero returns true if the ordinal is zero.
+func (v Veblen) IsZero() bool {
+	return len(v.terms) == 0
+}
+
+// IsSuccessor returns true if the ordinal is a successor ordinal, i.e. v + 1 for some v.
+func (v Veblen) IsSuccessor() bool {
+	return !v.IsZero() && v.terms[0].a.IsZero() && v.terms[0].b.IsZero()
+}
+
+// IsLimit returns true if the ordinal is a limit ordinal.
+func (v Veblen) IsLimit() bool {
+	return !v.IsZero() && !v.IsSuccessor()
+}
+
+// Add returns the ordinal sum v + w.
+// Ordinal addition is not commutative: 1 + omega is omega.
+func (v Veblen) Add(w Veblen) Veblen {
+	if w.IsZero() {
+		return v
+	}
+
+	// terms of v lower than the leading term of w are absorbed
+	lead := w.terms[len(w.terms)-1]
+	i := 0
+	for i < len(v.terms) && v.terms[i].cmp(lead) < 0 {
+		i++
+	}
+
+	sum := make([]vterm, 0, len(w.terms)+len(v.terms)-i)
+	sum = append(sum, w.terms...)
+	if i < len(v.terms) && v.terms[i].cmp(lead) == 0 {
+		// same term: coefficients are added
+		sum[len(sum)-1].coeff += v.terms[i].coeff
+		i++
+	}
+	sum = append(sum, v.terms[i:]...)
+	return Veblen{sum}
+}
+
+// Cmp compares v and w.
+// It returns -1, 0 or +1 depending on whether v is lower, equal or greater than w.
+func (v Veblen) Cmp(w Veblen) int {
+	i, j := len(v.terms)-1, len(w.terms)-1
+	for ; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := v.terms[i].cmp(w.terms[j]); c != 0 {
+			return c
+		}
+		switch {
+		case v.terms[i].coeff < w.terms[j].coeff:
+			return -1
+		case v.terms[i].coeff > w.terms[j].coeff:
+			return 1
+		}
+	}
+
+	// one of them is a prefix of the other one
+	switch {
+	case i >= 0:
+		return 1
+	case j >= 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// cmp compares phi(t.a, t.b) and phi(u.a, u.b), ignoring coefficients.
+func (t vterm) cmp(u vterm) int {
+	switch c := t.a.Cmp(u.a); {
+	case c == 0:
+		return t.b.Cmp(u.b)
+	case c < 0:
+		// phi(u.a, u.b) is a fixed point of phi(t.a, .)
+		if t.b.Cmp(u.phi()) < 0 {
+			return -1
+		}
+		return 1
+	default:
+		// phi(t.a, t.b) is a fixed point of phi(u.a, .)
+		if t.phi().Cmp(u.b) < 0 {
+			return -1
+		}
+		return 1
+	}
+}
+
+// phi returns phi(t.a, t.b), without the coefficient.
+func (t vterm) phi() Veblen {
+	return Veblen{[]vterm{{a: t.a, b: t.b, coeff: 1}}}
+}
+
+// predecessor returns the ordinal v - 1.
+// v must be a successor ordinal.
+func (v Veblen) predecessor() Veblen {
+	terms := make([]vterm, len(v.terms))
+	copy(terms, v.terms)
+	if terms[0].coeff--; terms[0].coeff == 0 {
+		terms = terms[1:]
+	}
+	return Veblen{terms}
+}
+
+// Fundamental returns the n-th element v[n] of the standard
+// fundamental sequence of the limit ordinal v:
+//   - (g + phi(0, b+1))[n] = g + phi(0, b) * n
+//   - (g + phi(a+1, 0))[n] = g + phi(a, .) iterated n times from 0
+//   - (g + phi(a+1, b+1))[n] = g + phi(a, .) iterated n times from phi(a+1, b) + 1
+//   - (g + phi(l, 0))[n] = g + phi(l[n], 0) if l is a limit ordinal
+//   - (g + phi(l, b+1))[n] = g + phi(l[n], phi(l, b) + 1) if l is a limit ordinal
+//   - (g + phi(a, l))[n] = g + phi(a, l[n]) if l is a limit ordinal.
+//
+// v must be a limit ordinal and n must be non negative.
+func (v Veblen) Fundamental(n int) Veblen {
+	if !v.IsLimit() {
+		panic("ordinal: fundamental sequence of a non limit ordinal")
+	}
+
+	// split v into g + phi(a, b)
+	least := v.terms[0]
+	g := Veblen{v.terms[1:]}
+	if least.coeff > 1 {
+		g = Veblen{append([]vterm{{least.a, least.b, least.coeff - 1}}, v.terms[1:]...)}
+	}
+	a, b := least.a, least.b
+
+	switch {
+	case b.IsLimit():
+		return g.Add(Phi(a, b.Fundamental(n)))
+
+	case a.IsZero():
+		// omega ^ (b+1)
+		if n == 0 {
+			return g
+		}
+		// (omega ^ b may be a fixed point, e.g. if b is epsilon_0)
+		t := Phi(Veblen{}, b.predecessor()).terms[0]
+		return g.Add(Veblen{[]vterm{{a: t.a, b: t.b, coeff: n}}})
+
+	case a.IsLimit() && b.IsZero():
+		return g.Add(Phi(a.Fundamental(n), Veblen{}))
+
+	case a.IsLimit():
+		return g.Add(Phi(a.Fundamental(n), Phi(a, b.predecessor()).Add(vone)))
+	}
+
+	// a is a successor: iterate phi(a-1, .)
+	var x Veblen
+	if !b.IsZero() {
+		x = Phi(a, b.predecessor()).Add(vone)
+	}
+	pred := a.predecessor()
+	for i := 0; i < n; i++ {
+		x = Phi(pred, x)
+	}
+	return g.Add(x)
+}
+
+// String returns a human readable ordinal
+// where most significant terms lie on the left
+// and least significant ones on the right, e.g. "φ(1, ω) * 2 + ω ^ (2)".
+// Terms phi(0, b) are written as powers of omega, like Ordinal.String.
+func (v Veblen) String() string {
+	if v.IsZero() {
+		return "0"
+	}
+
+	strTerms := make([]string, len(v.terms))
+	for i, t := range v.terms {
+		strTerms[len(v.terms)-1-i] = t.String()
+	}
+	return strings.Join(strTerms, " + ")
+}
+
+// LaTeX returns the LaTeX code of the ordinal, e.g. `\varphi(1, \omega) \cdot 2`.
+// It must be used in math mode.
+func (v Veblen) LaTeX() string {
+	if v.IsZero() {
+		return "0"
+	}
+
+	latexTerms := make([]string, len(v.terms))
+	for i, t := range v.terms {
+		latexTerms[len(v.terms)-1-i] = t.latex()
+	}
+	return strings.Join(latexTerms, " + ")
+}
+
+// String returns a human readable term.
+func (t vterm) String() string {
+	strCoeff := strconv.Itoa(t.coeff)
+
+	var power string
+	switch {
+	case t.a.IsZero() && t.b.IsZero():
+		// phi(0, 0) is one, so the term is equal to its coeff
+		return strCoeff
+	case t.a.IsZero() && t.b.Cmp(vone) == 0:
+		power = "ω"
+	case t.a.IsZero():
+		power = "ω ^ (" + t.b.String() + ")"
+	default:
+		power = "φ(" + t.a.String() + ", " + t.b.String() + ")"
+	}
+
+	if t.coeff == 1 {
+		return power
+	}
+	return power + " * " + strCoeff
+}
+
+// latex returns the LaTeX code of a term.
+func (t vterm) latex() string {
+	strCoeff := strconv.Itoa(t.coeff)
+
+	var power string
+	switch {
+	case t.a.IsZero() && t.b.IsZero():
+		return strCoeff
+	case t.a.IsZero() && t.b.Cmp(vone) == 0:
+		power = `\omega`
+	case t.a.IsZero():
+		power = `\omega^{` + t.b.LaTeX() + `}`
+	default:
+		power = `\varphi(` + t.a.LaTeX() + `, ` + t.b.LaTeX() + `)`
+	}
+
+	if t.coeff == 1 {
+		return power
+	}
+	return power + ` \cdot ` + strCoeff
+}
@@ -0,0 +1,149 @@
+package ordinal
+
+import (
+	"fmt"
+	"testing"
+)
+
+// epsilon0 is phi(1, 0).
+var epsilon0 = Phi(Nat(1).Veblen(), Veblen{})
+
+func ExamplePhi() {
+	fmt.Println(epsilon0)
+	fmt.Println(Phi(Veblen{}, epsilon0))
+	fmt.Println(epsilon0.Fundamental(3))
+	// Output:
+	// φ(1, 0)
+	// φ(1, 0)
+	// ω ^ (ω)
+}
+
+func TestVeblenString(t *testing.T) {
+	for _, g := range []struct {
+		v        Veblen
+		expected string
+	}{
+		{Veblen{}, "0"},
+		{Nat(3).Veblen(), "3"},
+		{OmegaPow(Omega).Add(Nat(2)).Veblen(), "ω ^ (ω) + 2"},
+		{epsilon0.Add(epsilon0).Add(Omega.Veblen()), "φ(1, 0) * 2 + ω"},
+		{Phi(Omega.Veblen(), epsilon0), "φ(ω, φ(1, 0))"},
+		{Nat(3).Veblen().Add(epsilon0), "φ(1, 0)"},
+	} {
+		if s := g.v.String(); s != g.expected {
+			t.Errorf("got %q, expecting %q", s, g.expected)
+		}
+	}
+}
+
+func TestVeblenCmp(t *testing.T) {
+	one := Nat(1).Veblen()
+	two := Nat(2).Veblen()
+	omega := Omega.Veblen()
+	epsilon1 := Phi(one, one)
+
+	// increasing ordinals
+	ordinals := []Veblen{
+		{},
+		one,
+		omega,
+		OmegaPow(OmegaPow(Omega)).Veblen(),
+		epsilon0,
+		epsilon0.Add(one),
+		epsilon0.Add(epsilon0),
+		Phi(Veblen{}, epsilon0.Add(one)), // ω ^ (ε_0 + 1)
+		epsilon1,
+		Phi(one, omega),
+		Phi(two, Veblen{}),
+		Phi(two, Veblen{}).Add(epsilon1),
+		Phi(omega, Veblen{}),
+		Phi(epsilon0, Veblen{}),
+	}
+	for i, a := range ordinals {
+		for j, b := range ordinals {
+			expected := 0
+			switch {
+			case i < j:
+				expected = -1
+			case i > j:
+				expected = 1
+			}
+			if c := a.Cmp(b); c != expected {
+				t.Errorf("comparing %v and %v: got %v, expecting %v", a, b, c, expected)
+			}
+		}
+	}
+}
+
+func TestVeblenFixedPoint(t *testing.T) {
+	// phi(1, .) enumerates fixed points of omega ^ .
+	epsilon1 := Phi(Nat(1).Veblen(), Nat(1).Veblen())
+	for _, v := range []Veblen{epsilon0, epsilon1, Phi(Nat(2).Veblen(), Veblen{})} {
+		if p := Phi(Veblen{}, v); p.Cmp(v) != 0 {
+			t.Errorf("ω ^ (%v) is %v", v, p)
+		}
+	}
+	if p := Phi(Nat(1).Veblen(), Phi(Nat(2).Veblen(), Veblen{})); p.String() != "φ(2, 0)" {
+		t.Errorf("φ(1, φ(2, 0)) is %v", p)
+	}
+}
+
+func TestVeblenConsistent(t *testing.T) {
+	// Cantor normal forms are Veblen normal forms
+	ordinals := []Ordinal{
+		Nat(0),
+		Nat(2),
+		Omega.Add(Nat(1)),
+		OmegaPow(Nat(2)).MulNat(3).Add(Omega),
+		OmegaPow(OmegaPow(Omega)),
+	}
+	for _, a := range ordinals {
+		v := a.Veblen()
+		if v.String() != a.String() {
+			t.Errorf("%v in Veblen normal form: %v", a, v)
+		}
+		if a.IsLimit() {
+			for n := 0; n < 4; n++ {
+				if f, g := v.Fundamental(n).String(), a.Fundamental(n).String(); f != g {
+					t.Errorf("%v[%v]: got %v, expecting %v", a, n, f, g)
+				}
+			}
+		}
+		for _, b := range ordinals {
+			if v.Cmp(b.Veblen()) != a.Cmp(b) {
+				t.Errorf("comparing %v and %v: inconsistent", a, b)
+			}
+		}
+	}
+}
+
+func TestVeblenFundamental(t *testing.T) {
+	one := Nat(1).Veblen()
+	two := Nat(2).Veblen()
+	omega := Omega.Veblen()
+	for _, g := range []struct {
+		v        Veblen
+		n        int
+		expected string
+	}{
+		{epsilon0, 0, "0"},
+		{epsilon0, 1, "1"},
+		{epsilon0, 2, "ω"},
+		{epsilon0.Add(epsilon0), 2, "φ(1, 0) + ω"},
+		{Phi(Veblen{}, epsilon0.Add(one)), 3, "φ(1, 0) * 3"},
+		{Phi(one, one), 0, "φ(1, 0) + 1"},
+		{Phi(one, one), 2, "ω ^ (ω ^ (φ(1, 0) + 1))"},
+		{Phi(one, omega), 3, "φ(1, 3)"},
+		{Phi(two, Veblen{}), 1, "φ(1, 0)"},
+		{Phi(two, Veblen{}), 2, "φ(1, φ(1, 0))"},
+		{Phi(omega, Veblen{}), 3, "φ(3, 0)"},
+		{Phi(omega, one), 2, "φ(2, φ(ω, 0) + 1)"},
+	} {
+		if f := g.v.Fundamental(g.n).String(); f != g.expected {
+			t.Errorf("%v[%v]: got %v, expecting %v", g.v, g.n, f, g.expected)
+		}
+		if g.v.Fundamental(g.n).Cmp(g.v) >= 0 {
+			t.Errorf("%v[%v] is not lower", g.v, g.n)
+		}
+	}
+}
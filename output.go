@@ -0,0 +1,111 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+)
+
+// compressed returns true if the named file must be gzip-compressed,
+// which is detected from its extension.
+// It returns an error for compression formats which are not supported.
+func compressed(name string) (bool, error) {
+	switch filepath.Ext(name) {
+	case ".gz":
+		return true, nil
+	case ".zst", ".zstd":
+		return false, fmt.Errorf("zstd compression is not supported, use gzip (.gz) instead")
+	default:
+		return false, nil
+	}
+}
+
+// gzipFile is a gzip-compressed file opened for writing.
+type gzipFile struct {
+	*gzip.Writer
+	f *os.File
+}
+
+// Close flushes the compressed stream and closes the file.
+func (g gzipFile) Close() error {
+	if err := g.Writer.Close(); err != nil {
+		g.f.Close()
+		return err
+	}
+	return g.f.Close()
+}
+
+// createOutput opens the named file for writing,
+// truncating it or appending to it.
+// Data is transparently compressed if the file name ends with .gz:
+// when appending, a new gzip member is added to the file.
+func createOutput(name string, append bool) (io.WriteCloser, error) {
+	gz, err := compressed(name)
+	if err != nil {
+		return nil, err
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if append {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(name, flag, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if !gz {
+		return f, nil
+	}
+	return gzipFile{gzip.NewWriter(f), f}, nil
+}
+
+// openInput opens the named file for reading.
+// Data is transparently decompressed if the file name ends with .gz.
+func openInput(name string) (io.ReadCloser, error) {
+	gz, err := compressed(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !gz {
+		return f, nil
+	}
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, f}, nil
+}
+
+// writeMetadata writes a block of comments describing the run
+// so that archived outputs are self-describing:
+// tool version, command-line arguments, first iteration with its base
+// and value, and date.
+func writeMetadata(w io.Writer, first, base *big.Int, value string) {
+	version := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		version = info.Main.Version
+	}
+
+	fmt.Fprintf(w, "# goodstein version: %v\n", version)
+	fmt.Fprintf(w, "# arguments: %q\n", os.Args[1:])
+	fmt.Fprintf(w, "# first iteration: %v\n", first)
+	fmt.Fprintf(w, "# start base: %v\n", base)
+	fmt.Fprintf(w, "# start value: %v\n", value)
+	fmt.Fprintf(w, "# date: %v\n", time.Now().Format(time.RFC3339))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// progress is a progress report of a running sequence, written on stderr by -stats.
+type progress struct {
+	Seed       *big.Int `json:"seed,omitempty"` // in batch mode only
+	Iteration  *big.Int `json:"iteration"`
+	Steps      int      `json:"steps"`
+	Total      int      `json:"total,omitempty"` // maximum number of steps, if any
+	Elapsed    float64  `json:"elapsed"`         // in seconds
+	Throughput float64  `json:"throughput"`      // in steps per second
+	Base       *big.Int `json:"base"`
+	Size       int      `json:"size"` // number of monomes of the decomposition
+	Depth      int      `json:"depth"`
+	Memory     uint64   `json:"memory"`        // allocated heap bytes
+	ETA        *float64 `json:"eta,omitempty"` // in seconds, if there is a maximum number of steps
+}
+
+// newProgress returns the progress of a sequence, keyed by seed in batch mode,
+// at iteration i with decomposition d after the given number of steps
+// out of total, if positive.
+// The ETA is an upper bound since the sequence may terminate
+// before the maximum number of steps is reached.
+func newProgress(seed, i *big.Int, d decomposition.Decomposition, steps, total int, elapsed time.Duration) progress {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	p := progress{
+		Seed:      seed,
+		Iteration: i,
+		Steps:     steps,
+		Elapsed:   elapsed.Seconds(),
+		Base:      d.Base(),
+		Size:      d.Size(),
+		Depth:     d.Depth(),
+		Memory:    mem.HeapAlloc,
+	}
+	if total > 0 {
+		p.Total = total
+	}
+	if p.Elapsed > 0 {
+		p.Throughput = float64(steps) / p.Elapsed
+	}
+	if total > 0 && steps > 0 {
+		eta := float64(max(total-steps, 0)) * p.Elapsed / float64(steps)
+		p.ETA = &eta
+	}
+	return p
+}
+
+// String returns the progress as a short line of text.
+func (p progress) String() string {
+	var b strings.Builder
+	if p.Seed != nil {
+		fmt.Fprintf(&b, "seed %v, ", p.Seed)
+	}
+	fmt.Fprintf(&b, "iteration %v, ", p.Iteration)
+	if p.Total > 0 {
+		fmt.Fprintf(&b, "%v/%v steps", p.Steps, p.Total)
+	} else {
+		fmt.Fprintf(&b, "%v steps", p.Steps)
+	}
+	fmt.Fprintf(&b, " in %v, %.1f it/s, base %v, size %v, depth %v, memory %.1f MiB",
+		time.Duration(p.Elapsed*float64(time.Second)).Round(time.Millisecond), p.Throughput,
+		p.Base, p.Size, p.Depth, float64(p.Memory)/(1<<20))
+	if p.ETA != nil {
+		fmt.Fprintf(&b, ", eta %v", time.Duration(*p.ETA*float64(time.Second)).Round(time.Second))
+	}
+	return b.String()
+}
+
+// reportStats writes the progress to w, usually stderr, like the standard logger
+// or as a JSON object on its own line if asJSON is true.
+func reportStats(w io.Writer, p progress, asJSON bool) {
+	if !asJSON {
+		log.New(w, "", log.LstdFlags).Print(p)
+		return
+	}
+
+	// a single write so that reports of concurrent sequences are not mixed up
+	data, err := json.Marshal(p)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	w.Write(append(data, '\n'))
+}
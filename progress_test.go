@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+func TestNewProgress(t *testing.T) {
+	d, err := decomposition.New(2, 19) // 2^(2^2) + 2 + 1
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range []struct {
+		steps, total int
+		elapsed      time.Duration
+		throughput   float64
+		eta          float64 // negative if there is no ETA
+	}{
+		{0, 0, 0, 0, -1},
+		{0, 100, time.Second, 0, -1},
+		{10, 0, 2 * time.Second, 5, -1},
+		{10, 100, 2 * time.Second, 5, 18},
+		{100, 100, 4 * time.Second, 25, 0},
+		// the total may be exceeded by the last step
+		{101, 100, time.Second, 101, 0},
+	} {
+		p := newProgress(nil, big.NewInt(7), d, g.steps, g.total, g.elapsed)
+		if p.Steps != g.steps || p.Total != g.total || p.Elapsed != g.elapsed.Seconds() {
+			t.Errorf("%v/%v in %v: got %v/%v in %v", g.steps, g.total, g.elapsed, p.Steps, p.Total, p.Elapsed)
+		}
+		if p.Throughput != g.throughput {
+			t.Errorf("%v in %v: got throughput %v, expecting %v", g.steps, g.elapsed, p.Throughput, g.throughput)
+		}
+		switch {
+		case g.eta < 0 && p.ETA != nil:
+			t.Errorf("%v/%v in %v: got ETA %v, expecting none", g.steps, g.total, g.elapsed, *p.ETA)
+		case g.eta >= 0 && p.ETA == nil:
+			t.Errorf("%v/%v in %v: got no ETA, expecting %v", g.steps, g.total, g.elapsed, g.eta)
+		case g.eta >= 0 && *p.ETA != g.eta:
+			t.Errorf("%v/%v in %v: got ETA %v, expecting %v", g.steps, g.total, g.elapsed, *p.ETA, g.eta)
+		}
+		if p.Iteration.Int64() != 7 || p.Base.Int64() != 2 || p.Size != d.Size() || p.Depth != d.Depth() {
+			t.Errorf("got iteration %v, base %v, size %v and depth %v", p.Iteration, p.Base, p.Size, p.Depth)
+		}
+	}
+}
+
+func TestProgressString(t *testing.T) {
+	eta := 90.4
+	for _, g := range []struct {
+		p        progress
+		expected string
+	}{
+		{
+			progress{Iteration: big.NewInt(3), Steps: 3, Elapsed: 1.5, Throughput: 2, Base: big.NewInt(5), Size: 4, Depth: 2, Memory: 3 << 20},
+			"iteration 3, 3 steps in 1.5s, 2.0 it/s, base 5, size 4, depth 2, memory 3.0 MiB",
+		},
+		{
+			progress{Seed: big.NewInt(4), Iteration: big.NewInt(10), Steps: 10, Total: 100, Elapsed: 10, Throughput: 1, Base: big.NewInt(12), Size: 3, Depth: 3, Memory: 1 << 19, ETA: &eta},
+			"seed 4, iteration 10, 10/100 steps in 10s, 1.0 it/s, base 12, size 3, depth 3, memory 0.5 MiB, eta 1m30s",
+		},
+	} {
+		if s := g.p.String(); s != g.expected {
+			t.Errorf("got %q, expecting %q", s, g.expected)
+		}
+	}
+}
+
+func TestReportStats(t *testing.T) {
+	eta := 2.0
+	p := progress{Iteration: big.NewInt(3), Steps: 3, Total: 5, Elapsed: 3, Throughput: 1, Base: big.NewInt(5), Size: 1, Depth: 1, ETA: &eta}
+
+	var text bytes.Buffer
+	reportStats(&text, p, false)
+	if s := text.String(); !strings.HasSuffix(s, " "+p.String()+"\n") || strings.Count(s, "\n") != 1 {
+		t.Errorf("got %q, expecting a log line of %q", s, p)
+	}
+
+	var data bytes.Buffer
+	reportStats(&data, p, true)
+	if !strings.HasSuffix(data.String(), "}\n") || strings.Count(data.String(), "\n") != 1 {
+		t.Errorf("got %q, expecting a single JSON line", data.String())
+	}
+	var got progress
+	if err := json.Unmarshal(data.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Seed != nil || got.Iteration.Cmp(p.Iteration) != 0 || got.Steps != p.Steps || got.Total != p.Total ||
+		got.Throughput != p.Throughput || got.Base.Cmp(p.Base) != 0 || got.ETA == nil || *got.ETA != eta {
+		t.Errorf("got %+v, expecting %+v", got, p)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// state is the state of a sequence at a given iteration.
+type state struct {
+	iteration *big.Int
+	d         decomposition.Decomposition
+}
+
+// line is a parsed iteration line of the output of the command.
+type line struct {
+	iteration     *big.Int
+	base          *big.Int
+	value         *big.Int // nil if the value was not computed
+	decomposition string   // as printed by String or LaTeX
+}
+
+// isIterationLine returns true if the text is an iteration line
+// and not a metadata, header or summary line.
+func isIterationLine(text string) bool {
+	return !strings.HasPrefix(text, "#") && strings.HasSuffix(text, `"`)
+}
+
+// parseLine parses an iteration line.
+func parseLine(text string) (line, error) {
+	// the decomposition is quoted at the end of the line
+	quote := strings.Index(text, `"`)
+	d, err := strconv.Unquote(text[quote:])
+	if err != nil {
+		return line{}, fmt.Errorf("invalid decomposition %v", text[quote:])
+	}
+
+	// iteration, base and value are the last fields before the quoted decomposition
+	// (timestamps, if any, come first)
+	fields := strings.Fields(text[:quote])
+	if len(fields) < 3 {
+		return line{}, fmt.Errorf("invalid line %q", text)
+	}
+	fields = fields[len(fields)-3:]
+
+	i, ok := new(big.Int).SetString(fields[0], 10)
+	if !ok {
+		return line{}, fmt.Errorf("invalid iteration %q", fields[0])
+	}
+	b, ok := new(big.Int).SetString(fields[1], 10)
+	if !ok {
+		return line{}, fmt.Errorf("invalid base %q", fields[1])
+	}
+	if fields[2] == "-" {
+		// value was not computed
+		return line{i, b, nil, d}, nil
+	}
+	value, ok := new(big.Int).SetString(fields[2], 10)
+	if !ok {
+		return line{}, fmt.Errorf("invalid value %q", fields[2])
+	}
+	return line{i, b, value, d}, nil
+}
+
+// lastState reads a previous output of the command
+// and returns the state of its last iteration.
+// It returns false if there is no iteration in the output.
+func lastState(r io.Reader) (state, bool, error) {
+	// find the last iteration line
+	var last string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<30) // decompositions may be very long
+	for scanner.Scan() {
+		if text := scanner.Text(); isIterationLine(text) {
+			last = text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return state{}, false, err
+	}
+	if last == "" {
+		return state{}, false, nil
+	}
+
+	l, err := parseLine(last)
+	if err != nil {
+		return state{}, false, err
+	}
+
+	// the decomposition is parsed rather than computed from the value,
+	// which may be missing or too large
+	d, err := decomposition.ParseBaseBig(l.base, l.decomposition)
+	if err != nil {
+		return state{}, false, err
+	}
+	return state{l.iteration, d}, true, nil
+}
+
+// openAppend opens the file for appending and reads its last iteration, if any.
+func openAppend(name string) (io.WriteCloser, state, bool, error) {
+	// read the last iteration of an existing file
+	var (
+		last state
+		ok   bool
+	)
+	r, err := openInput(name)
+	switch {
+	case os.IsNotExist(err):
+		// nothing to resume from
+	case err != nil:
+		return nil, state{}, false, err
+	default:
+		last, ok, err = lastState(r)
+		r.Close()
+		if err != nil {
+			return nil, state{}, false, fmt.Errorf("cannot resume from %v: %v", name, err)
+		}
+	}
+
+	// and open it for appending
+	w, err := createOutput(name, true)
+	if err != nil {
+		return nil, state{}, false, err
+	}
+	return w, last, ok, nil
+}
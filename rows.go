@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/batiazinga/goodstein/decomposition"
+)
+
+// row is an iteration as written by structured formats:
+// -format jsonl writes one JSON object per line
+// and -format csv or tsv one record per line.
+// Optional columns are omitted unless requested by the corresponding flags
+// (or by several seeds, for the seed column).
+type row struct {
+	Seed          *big.Int `json:"seed,omitempty"`
+	Time          string   `json:"time,omitempty"`
+	Elapsed       *float64 `json:"elapsed,omitempty"`
+	Growth        string   `json:"growth,omitempty"`
+	Iteration     *big.Int `json:"iteration"`
+	Base          *big.Int `json:"base"`
+	Value         *big.Int `json:"value"`  // null if not computed
+	Digits        *big.Int `json:"digits"` // null if too large to be estimated
+	Decomposition string   `json:"decomposition"`
+	LaTeX         string   `json:"latex"`
+}
+
+// newRow returns the row of the i-th iteration, whose value may be nil.
+func newRow(i *big.Int, d decomposition.Decomposition, value *big.Int) row {
+	return row{
+		Iteration:     i,
+		Base:          d.Base(),
+		Value:         value,
+		Digits:        d.NumDecimalDigits(),
+		Decomposition: d.String(),
+		LaTeX:         d.LaTeX(),
+	}
+}
+
+// rowWriter writes rows in a structured format.
+type rowWriter interface {
+	// header writes the names of the columns, if the format has any.
+	header() error
+	write(r row) error
+	flush() error
+}
+
+// newRowWriter returns a writer of rows in the named format,
+// with a seed column if seed is true, time and elapsed columns if stamps is true
+// and a growth column if growth is true.
+func newRowWriter(w io.Writer, format string, seed, stamps, growth bool) rowWriter {
+	switch format {
+	case "csv":
+		return &csvWriter{csv.NewWriter(w), seed, stamps, growth}
+	case "tsv":
+		cw := csv.NewWriter(w)
+		cw.Comma = '\t'
+		return &csvWriter{cw, seed, stamps, growth}
+	default:
+		return jsonWriter{json.NewEncoder(w)}
+	}
+}
+
+// jsonWriter writes rows as JSON Lines.
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (jsonWriter) header() error       { return nil }
+func (j jsonWriter) write(r row) error { return j.enc.Encode(r) }
+func (jsonWriter) flush() error        { return nil }
+
+// csvWriter writes rows as CSV records, with proper quoting.
+// Values which were not computed and digits which cannot be estimated are empty.
+type csvWriter struct {
+	w                    *csv.Writer
+	seed, stamps, growth bool
+}
+
+func (c *csvWriter) header() error {
+	defer c.w.Flush()
+	var names []string
+	if c.seed {
+		names = append(names, "seed")
+	}
+	if c.stamps {
+		names = append(names, "time", "elapsed")
+	}
+	if c.growth {
+		names = append(names, "growth")
+	}
+	names = append(names, "iteration", "base", "value", "digits", "decomposition", "latex")
+	return c.w.Write(names)
+}
+
+func (c *csvWriter) write(r row) error {
+	var record []string
+	if c.seed {
+		record = append(record, r.Seed.String())
+	}
+	if c.stamps {
+		record = append(record, r.Time, strconv.FormatFloat(*r.Elapsed, 'f', 6, 64))
+	}
+	if c.growth {
+		record = append(record, r.Growth)
+	}
+	record = append(record, r.Iteration.String(), r.Base.String(), optional(r.Value), optional(r.Digits), r.Decomposition, r.LaTeX)
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+	// flush every record so that long runs can be followed
+	return c.flush()
+}
+
+func (c *csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// optional returns n as a string, or an empty string if n is nil.
+func optional(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
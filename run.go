@@ -0,0 +1,428 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/batiazinga/goodstein/decomposition"
+	"github.com/batiazinga/goodstein/goodstein"
+)
+
+// runner runs Goodstein sequences as configured by the flags of the command,
+// writing their iterations to a shared output.
+type runner struct {
+	out      io.Writer
+	rows     rowWriter             // nil for text output
+	opts     []goodstein.Option    // base rule and subtraction, none for standard sequences
+	sampled  func(i *big.Int) bool // iterations to report
+	inWindow func(i *big.Int) bool // iterations between from and to
+	from, to *big.Int              // nil if not set
+	cert     *certificate          // nil if no certificate is written
+	seed     *big.Int              // in batch mode, seed written before each iteration, nil otherwise
+}
+
+// runAll runs the sequences starting from states, whose seeds are written in batch mode,
+// on the given number of concurrent workers.
+// It returns false if a safety limit was hit by a sequence.
+// The first error of a sequence ends the run, after the output of the previous ones.
+//
+// With several workers, each sequence is written to a buffer
+// and the buffers are copied to the output in the order of the states,
+// so that the output does not depend on scheduling.
+func (rn runner) runAll(states []state, seeds []*big.Int, workers int) (bool, error) {
+	ok := true
+
+	// one sequence after the other, directly to the output
+	if workers <= 1 || len(states) <= 1 {
+		for k, s := range states {
+			if seeds != nil {
+				rn.seed = seeds[k]
+			}
+			seqOK, err := rn.run(s.iteration, s.d)
+			if err != nil {
+				return false, err
+			}
+			if !seqOK {
+				ok = false
+			}
+		}
+		return ok, nil
+	}
+
+	// output of a sequence
+	type result struct {
+		buf bytes.Buffer
+		ok  bool
+		err error
+	}
+	results := make([]chan *result, len(states))
+	for k := range results {
+		results[k] = make(chan *result, 1)
+	}
+
+	// workers run sequences in the order of the states
+	jobs := make(chan int)
+	go func() {
+		for k := range states {
+			jobs <- k
+		}
+		close(jobs)
+	}()
+	for range workers {
+		go func() {
+			for k := range jobs {
+				res := new(result)
+				w := rn
+				w.out = &res.buf
+				if seeds != nil {
+					w.seed = seeds[k]
+				}
+				if rn.rows != nil {
+					w.rows = newRowWriter(&res.buf, *outFormat, seeds != nil, *stamps, *growth)
+				}
+				res.ok, res.err = w.run(states[k].iteration, states[k].d)
+				if w.rows != nil && res.err == nil {
+					res.err = w.rows.flush()
+				}
+				results[k] <- res
+			}
+		}()
+	}
+
+	// copy outputs as soon as all previous sequences are written
+	for _, c := range results {
+		res := <-c
+		if _, err := res.buf.WriteTo(rn.out); err != nil {
+			return false, err
+		}
+		if res.err != nil {
+			return false, res.err
+		}
+		if !res.ok {
+			ok = false
+		}
+	}
+	return ok, nil
+}
+
+// checkpoint saves the computed iteration s to the -checkpoint file.
+func (rn *runner) checkpoint(s state) error {
+	c := checkpoint{state: s, bump: *bump, subtract: *subtract}
+	if err := writeCheckpoint(*ckpt, c); err != nil {
+		return fmt.Errorf("checkpoint: %v", err)
+	}
+	return nil
+}
+
+// maxLengthSeed is the largest seed whose length is used to bound runs until zero:
+// the length of larger seeds does not fit in an int.
+const maxLengthSeed = 3
+
+// bound returns the maximum number of steps of a run from decomposition d at iteration first,
+// or 0 if it is unknown or does not fit in an int.
+// The run steps after each iteration up to the last one
+// (to, or the it-th one from the start of the window),
+// but not beyond zero nor the maximum number of steps.
+func (rn *runner) bound(first *big.Int, d decomposition.Decomposition) int {
+	var bound *big.Int
+
+	// steps up to the last iteration
+	var last *big.Int
+	switch {
+	case rn.to != nil:
+		last = rn.to
+	case !*untilZero:
+		last = new(big.Int).Set(first)
+		if rn.from != nil && rn.from.Cmp(first) > 0 {
+			last.Set(rn.from)
+		}
+		last.Add(last, big.NewInt(int64(*it)-1))
+	}
+	if last != nil {
+		bound = new(big.Int).Sub(last, first)
+		bound.Add(bound, one)
+		if bound.Sign() < 0 {
+			bound.SetInt64(0)
+		}
+	}
+
+	// steps up to zero, for small seeds of standard sequences
+	if first.Sign() == 0 && len(rn.opts) == 0 {
+		if seed := d.Eval(); seed.Cmp(big.NewInt(maxLengthSeed)) <= 0 {
+			length, _, err := goodstein.Length(int(seed.Int64()))
+			if err == nil && (bound == nil || length.Cmp(bound) < 0) {
+				bound = length
+			}
+		}
+	}
+
+	// steps up to the safety limit
+	if *maxSteps > 0 {
+		if limit := big.NewInt(int64(*maxSteps)); bound == nil || limit.Cmp(bound) < 0 {
+			bound = limit
+		}
+	}
+
+	if bound == nil || !bound.IsInt64() || bound.Int64() > math.MaxInt {
+		return 0
+	}
+	return int(bound.Int64())
+}
+
+// run iterates the sequence from decomposition d at iteration first.
+// It returns false if a safety limit was hit before the sequence terminated.
+// Invalid steps and certificates are exitErrors with status 2.
+func (rn *runner) run(first *big.Int, d decomposition.Decomposition) (bool, error) {
+	// statistics for the summary
+	start := time.Now()
+	var (
+		steps      int    // number of iterations executed
+		terminated bool   // true if the decomposition reached zero
+		limit      string // safety limit hit before zero, if any
+	)
+	maxDigits := new(big.Int) // number of digits of the largest value, nil if too large
+
+	// last time progress was reported
+	lastStats := start
+
+	// maximum number of steps for ETAs, if known
+	total := rn.bound(first, d)
+
+	// iterations go on until the end of the window, zero
+	// or the maximum number of iterations in the window
+	more := func(n int, i *big.Int) bool {
+		switch {
+		case rn.to != nil:
+			return i.Cmp(rn.to) <= 0
+		case *untilZero:
+			return true
+		default:
+			return n < *it
+		}
+	}
+
+	// true once an iteration has been written in an align* environment
+	var aligned bool
+
+	// previous iteration, for growth factors
+	var previous *decomposition.Decomposition
+
+	// values are only computed when needed
+	// (a decomposition caches its value, so that it is computed once per iteration)
+	eval := func() *big.Int { return d.Eval() }
+
+	// current stretch of iterations with the same shape
+	var (
+		stretchStart = first
+		stretchShape = d
+		last         = first // last iteration
+	)
+
+	// last computed iteration and last time it was saved, for checkpoints
+	var (
+		computed       state
+		lastCheckpoint = start
+	)
+
+	// start iterations:
+	// the iteration index may exceed any fixed-size integer when resuming long runs
+	// so it is a *big.Int, a new one for each iteration
+	seq, err := goodstein.NewSequenceFrom(d, first, rn.opts...)
+	if err != nil {
+		return false, err
+	}
+	var i *big.Int
+	for n := 0; seq.Next(); steps++ {
+		i, d = seq.Step(), seq.Current()
+		if !more(n, i) {
+			break
+		}
+
+		// iterations before the window are computed silently
+		reported := rn.inWindow(i)
+		if reported {
+			n++
+		}
+
+		// detect the end of a stable stretch (or not), from the start of the window
+		if *stable && reported && (!d.SameShape(stretchShape) || rn.from != nil && i.Cmp(rn.from) == 0) {
+			reportStretch(rn.out, stretchStart, last)
+			stretchStart, stretchShape = i, d
+		}
+		if reported {
+			last = i
+		}
+
+		// report progress (or not)
+		if *stats > 0 && time.Since(lastStats) >= *stats {
+			lastStats = time.Now()
+			reportStats(os.Stderr, newProgress(rn.seed, i, d, steps, total, lastStats.Sub(start)), *statsJSON)
+		}
+
+		if *summary {
+			// only keep track of the number of digits of the largest value (if computed),
+			// which does not require its evaluation
+			if !*noValue && maxDigits != nil {
+				if digits := d.NumDecimalDigits(); digits == nil || digits.Cmp(maxDigits) > 0 {
+					maxDigits = digits
+				}
+			}
+		} else if rn.sampled(i) && rn.rows != nil {
+			// print a structured row if this iteration is sampled
+			var value *big.Int
+			if !*noValue {
+				value = eval()
+			}
+			r := newRow(i, d, value)
+			r.Seed = rn.seed
+			if *stamps {
+				now := time.Now()
+				elapsed := now.Sub(start).Seconds()
+				r.Time, r.Elapsed = now.Format(timestampLayout), &elapsed
+			}
+			if *growth {
+				r.Growth = growthFactor(d, previous)
+			}
+			if err := rn.rows.write(r); err != nil {
+				return false, err
+			}
+		} else if rn.sampled(i) {
+			// print result to stdout if this iteration is sampled
+			var strDecomposition string
+			if *table {
+				strDecomposition = d.BreakableLaTeX()
+			} else if *latex {
+				strDecomposition = d.LaTeX()
+			} else {
+				strDecomposition = d.String()
+			}
+			if rn.seed != nil {
+				fmt.Fprintf(rn.out, "%v ", rn.seed)
+			}
+			if *stamps {
+				now := time.Now()
+				fmt.Fprintf(rn.out, "%v %.6f ", now.Format(timestampLayout), now.Sub(start).Seconds())
+			}
+			if *growth {
+				fmt.Fprintf(rn.out, "%v ", growthFactor(d, previous))
+			}
+			if *digits {
+				fmt.Fprintf(rn.out, "%v ", numDigits(d))
+			}
+			// evaluation is by far the most expensive part
+			strValue := "-"
+			if !*noValue {
+				strValue = eval().String()
+			}
+			if *table {
+				writeLongtableRow(rn.out, i, d.Base(), strValue, strDecomposition)
+			} else if *document {
+				writeAlignRow(rn.out, i, d, !aligned)
+				aligned = true
+			} else {
+				fmt.Fprintf(rn.out, "%v %v %v %q\n", i, d.Base(), strValue, strDecomposition)
+			}
+
+			// draw the decomposition (or not)
+			if *svgDir != "" {
+				name := i.String() + ".svg"
+				if rn.seed != nil {
+					name = rn.seed.String() + "-" + name
+				}
+				name = filepath.Join(*svgDir, name)
+				if err := os.WriteFile(name, []byte(d.SVG()+"\n"), 0644); err != nil {
+					return false, err
+				}
+			}
+		}
+		prev := d
+		previous = &prev
+
+		// certify every iteration (or not)
+		if rn.cert != nil {
+			if err := rn.cert.write(i, d); err != nil {
+				return false, exitError{2, fmt.Errorf("invalid certificate: %v", err)}
+			}
+		}
+
+		// save the sequence periodically (or not)
+		computed = state{iteration: i, d: d}
+		if *ckpt != "" && time.Since(lastCheckpoint) >= *ckptEvery {
+			lastCheckpoint = time.Now()
+			if err := rn.checkpoint(computed); err != nil {
+				return false, err
+			}
+		}
+
+		// if decomposition is zero, stop
+		if d.IsZero() {
+			terminated = true
+			break
+		}
+
+		// stop before the next step if a safety limit is hit
+		if *maxSteps > 0 && steps >= *maxSteps {
+			limit = fmt.Sprintf("max-steps %v", *maxSteps)
+			break
+		}
+		if *maxSecs > 0 && time.Since(start).Seconds() >= *maxSecs {
+			limit = fmt.Sprintf("max-seconds %v", *maxSecs)
+			break
+		}
+	}
+	if err := seq.Err(); err != nil {
+		return false, exitError{2, err}
+	}
+
+	// save the end of the sequence (or not)
+	if *ckpt != "" && computed.iteration != nil {
+		if err := rn.checkpoint(computed); err != nil {
+			return false, err
+		}
+	}
+
+	// conclude the certificate (or not)
+	if rn.cert != nil {
+		rn.cert.end(terminated)
+	}
+
+	// report the last stretch (or not)
+	if *stable {
+		reportStretch(rn.out, stretchStart, last)
+	}
+
+	// print summary (or not)
+	if *summary {
+		if rn.seed != nil {
+			fmt.Fprintf(rn.out, "seed: %v\n", rn.seed)
+		}
+		fmt.Fprintf(rn.out, "steps: %v\n", steps)
+		fmt.Fprintf(rn.out, "terminated: %v\n", terminated)
+		if limit != "" {
+			fmt.Fprintf(rn.out, "limit: %v\n", limit)
+		}
+		if *noValue || maxDigits == nil {
+			fmt.Fprintln(rn.out, "max value digits: -")
+		} else {
+			fmt.Fprintf(rn.out, "max value digits: %v\n", maxDigits)
+		}
+		fmt.Fprintf(rn.out, "elapsed: %v\n", time.Since(start))
+	}
+
+	if limit != "" {
+		if rn.seed != nil {
+			log.Printf("seed %v: limit hit: %v, after %v steps", rn.seed, limit, steps)
+		} else {
+			log.Printf("limit hit: %v, after %v steps", limit, steps)
+		}
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/batiazinga/goodstein/decomposition"
+	"github.com/batiazinga/goodstein/goodstein"
+)
+
+func TestRunnerBound(t *testing.T) {
+	defer func(n int, zero bool, m int) {
+		*it, *untilZero, *maxSteps = n, zero, m
+	}(*it, *untilZero, *maxSteps)
+
+	big0, _ := decomposition.New(2, 100) // seed far too large for its length
+	small, _ := decomposition.New(2, 3)  // sequence of length 5
+	for _, g := range []struct {
+		d         decomposition.Decomposition
+		first     int64
+		from, to  int64 // -1 if not set
+		it        int
+		untilZero bool
+		maxSteps  int
+		opts      []goodstein.Option
+		expected  int
+	}{
+		// it iterations from the first one or the start of the window
+		{big0, 0, -1, -1, 10, false, 0, nil, 10},
+		{big0, 0, 5, -1, 10, false, 0, nil, 15},
+		{big0, 7, 5, -1, 10, false, 0, nil, 10},
+		{big0, 7, 20, -1, 10, false, 0, nil, 23},
+		{big0, 0, -1, -1, 0, false, 0, nil, 0},
+		// up to the end of the window, whatever it and until-zero
+		{big0, 0, -1, 30, 10, false, 0, nil, 31},
+		{big0, 0, 5, 30, 10, true, 0, nil, 31},
+		{big0, 10, -1, 30, 10, false, 0, nil, 21},
+		{big0, 40, -1, 30, 10, false, 0, nil, 0},
+		// until zero, bounded by the maximum number of steps only
+		{big0, 0, -1, -1, 10, true, 0, nil, 0},
+		{big0, 0, -1, -1, 10, true, 50, nil, 50},
+		{big0, 0, -1, 30, 10, false, 20, nil, 20},
+		// until zero, bounded by the length of small seeds of standard sequences
+		{small, 0, -1, -1, 10, true, 0, nil, 5},
+		{small, 0, -1, -1, 3, false, 0, nil, 3},
+		{small, 0, -1, -1, 10, false, 4, nil, 4},
+		{small, 0, -1, -1, 10, true, 0, []goodstein.Option{goodstein.Bump(goodstein.AddBase(2))}, 0},
+		{small, 0, -1, -1, 10, true, 0, []goodstein.Option{goodstein.Subtract(2)}, 0},
+		{small, 2, -1, -1, 10, true, 0, nil, 0},
+	} {
+		rn := runner{opts: g.opts}
+		if g.from >= 0 {
+			rn.from = big.NewInt(g.from)
+		}
+		if g.to >= 0 {
+			rn.to = big.NewInt(g.to)
+		}
+		*it, *untilZero, *maxSteps = g.it, g.untilZero, g.maxSteps
+		if bound := rn.bound(big.NewInt(g.first), g.d); bound != g.expected {
+			t.Errorf("%v at %v, from %v to %v, it %v, until-zero %v, max-steps %v, %v options: got %v, expecting %v",
+				g.d, g.first, g.from, g.to, g.it, g.untilZero, g.maxSteps, len(g.opts), bound, g.expected)
+		}
+	}
+}
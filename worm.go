@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/batiazinga/goodstein/worm"
+)
+
+// wormCommand prints a worm battle step by step.
+func wormCommand(args []string) error {
+	flags := flag.NewFlagSet("worm", flag.ExitOnError)
+	maxIt := flags.Int("it", 100, "maximum number of steps")
+	certify := flags.String("certificate", "", "if not empty, file where a termination certificate is written: every worm with its ordinal, asserted to strictly decrease")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expecting one worm, e.g. \"2 0 1\"")
+	}
+	if *maxIt < 0 {
+		return fmt.Errorf("it must be positive")
+	}
+
+	w, err := worm.Parse(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid worm: %v", err)
+	}
+
+	// write a termination certificate (or not)
+	var cert io.Writer
+	if *certify != "" {
+		f, err := createOutput(*certify, false)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		cert = f
+		fmt.Fprintln(cert, "# worm termination certificate: step worm ordinal")
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	fmt.Fprintln(out, "step length worm")
+	s := worm.NewStepper(w)
+	previous := s.Worm()
+	for {
+		fmt.Fprintf(out, "%v %v %v\n", s.Step(), len(s.Worm()), s.Worm())
+		if cert != nil {
+			if s.Step() > 0 {
+				if err := worm.CheckStep(previous, s.Worm()); err != nil {
+					return fmt.Errorf("invalid certificate at step %v: %v", s.Step(), err)
+				}
+			}
+			previous = s.Worm()
+			fmt.Fprintf(cert, "%v %q %q\n", s.Step(), s.Worm(), s.Worm().Ordinal())
+		}
+		if s.Step() == *maxIt || !s.Next() {
+			break
+		}
+	}
+
+	if cert != nil {
+		if s.Worm().IsDead() {
+			fmt.Fprintln(cert, "# dead: ordinals strictly decreased down to 0")
+		} else {
+			fmt.Fprintln(cert, "# not dead: ordinals strictly decreased so far")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+/*
+Package worm implements the worm battle of Beklemishev.
+
+A worm is a finite sequence of natural numbers whose head is its last element.
+At step n, if the head is 0 it is removed.
+Otherwise let r be the longest prefix ending with an element lower than the head,
+and s the rest of the worm with its head decremented:
+the worm becomes r followed by n + 1 copies of s.
+Although worms grow extremely fast, every worm eventually dies, i.e. becomes empty,
+which like the termination of Goodstein sequences cannot be proved in Peano arithmetic.
+*/
+package worm
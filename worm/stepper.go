@@ -0,0 +1,39 @@
+package worm
+
+// Stepper follows a worm step by step.
+// Like bufio.Scanner, successive calls to Next advance the worm by one step:
+//
+//	s := NewStepper(w)
+//	for s.Next() {
+//		fmt.Println(s.Step(), s.Worm())
+//	}
+type Stepper struct {
+	worm Worm
+	step int
+}
+
+// NewStepper returns a stepper starting from the worm.
+func NewStepper(w Worm) *Stepper {
+	return &Stepper{worm: w}
+}
+
+// Next advances the worm to its next step.
+// It returns false if the worm is already dead.
+func (s *Stepper) Next() bool {
+	if s.worm.IsDead() {
+		return false
+	}
+	s.step++
+	s.worm = s.worm.Next(s.step)
+	return true
+}
+
+// Step returns the number of steps so far.
+func (s *Stepper) Step() int {
+	return s.step
+}
+
+// Worm returns the current worm.
+func (s *Stepper) Worm() Worm {
+	return s.worm
+}
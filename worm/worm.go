@@ -0,0 +1,120 @@
+package worm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/batiazinga/goodstein/ordinal"
+)
+
+// Worm is a worm, from its tail to its head.
+// Worms are never modified once built: Next returns a new one.
+type Worm []int
+
+// Parse parses a worm as printed by String, i.e. its elements separated by spaces,
+// e.g. "2 0 1". Commas are accepted as separators too.
+func Parse(s string) (Worm, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	w := make(Worm, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid element %q", f)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("negative element %v", n)
+		}
+		w[i] = n
+	}
+	return w, nil
+}
+
+// String returns the elements of the worm separated by spaces,
+// or "-" for the empty worm.
+func (w Worm) String() string {
+	if w.IsDead() {
+		return "-"
+	}
+	elems := make([]string, len(w))
+	for i, n := range w {
+		elems[i] = strconv.Itoa(n)
+	}
+	return strings.Join(elems, " ")
+}
+
+// IsDead returns true if the worm is empty.
+func (w Worm) IsDead() bool {
+	return len(w) == 0
+}
+
+// Next returns the worm after step n:
+// a zero head is removed, otherwise the worm becomes r followed by n + 1 copies of s,
+// where r is the longest prefix ending with an element lower than the head
+// and s the rest of the worm with its head decremented.
+// The worm must not be dead and n must be non negative.
+func (w Worm) Next(n int) Worm {
+	if w.IsDead() {
+		panic("worm: step of a dead worm")
+	}
+	if n < 0 {
+		panic("worm: negative step")
+	}
+
+	head := w[len(w)-1]
+	if head == 0 {
+		return w[: len(w)-1 : len(w)-1]
+	}
+
+	// r is w[:k], s is w[k:] with its head decremented
+	k := len(w) - 1
+	for k > 0 && w[k-1] >= head {
+		k--
+	}
+	s := make(Worm, len(w)-k)
+	copy(s, w[k:])
+	s[len(s)-1]--
+
+	next := make(Worm, 0, k+(n+1)*len(s))
+	next = append(next, w[:k]...)
+	for i := 0; i <= n; i++ {
+		next = append(next, s...)
+	}
+	return next
+}
+
+// Ordinal returns the ordinal of the worm, which strictly decreases at each step,
+// so that a worm with ordinal n < ω dies after n steps.
+// It is defined recursively by o(∅) = 0, o(w) = ω ^ o(w - 1) if w has no zero element,
+// and o(w_1 0 w_0) = o(w_1) + ω ^ o(w_0 - 1) where w_0 has no zero element,
+// w - 1 being w with all elements decremented.
+func (w Worm) Ordinal() ordinal.Ordinal {
+	// split the worm at its zeros: A_0 0 A_1 0 ... 0 A_k,
+	// whose ordinal is ω ^ o(A_0 - 1) + ... + ω ^ o(A_k - 1),
+	// except that an empty A_0 has no term
+	var (
+		terms []ordinal.Ordinal
+		block Worm
+		first = true
+	)
+	for i := 0; i <= len(w); i++ {
+		if i < len(w) && w[i] > 0 {
+			block = append(block, w[i]-1)
+			continue
+		}
+		if !first || len(block) > 0 {
+			terms = append(terms, ordinal.OmegaPow(block.Ordinal()))
+		}
+		first, block = false, nil
+	}
+	return ordinal.Sum(terms...)
+}
+
+// CheckStep returns an error if the ordinal of after is not lower than the one of before,
+// which never happens when after results from a step of before.
+func CheckStep(before, after Worm) error {
+	if a, b := after.Ordinal(), before.Ordinal(); a.Cmp(b) >= 0 {
+		return fmt.Errorf("ordinal %v of %v is not lower than ordinal %v of %v", a, after, b, before)
+	}
+	return nil
+}
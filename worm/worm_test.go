@@ -0,0 +1,136 @@
+package worm
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func ExampleWorm_Next() {
+	w, _ := Parse("0 2 1 2")
+	fmt.Println(w.Next(2))
+	// Output:
+	// 0 2 1 1 1 1
+}
+
+func TestParseString(t *testing.T) {
+	for _, g := range []struct {
+		s, expected string
+	}{
+		{"", "-"},
+		{"0", "0"},
+		{"2 0 1", "2 0 1"},
+		{" 2,0,  1 ", "2 0 1"},
+	} {
+		w, err := Parse(g.s)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", g.s, err)
+			continue
+		}
+		if w.String() != g.expected {
+			t.Errorf("%q: got %q, expecting %q", g.s, w, g.expected)
+		}
+	}
+	for _, s := range []string{"a", "1 -1", "1.5"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%q: expecting an error", s)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	for _, g := range []struct {
+		worm     string
+		n        int
+		expected string
+	}{
+		{"0", 1, "-"},
+		{"1 0", 1, "1"},
+		{"1", 1, "0 0"},
+		{"1", 3, "0 0 0 0"},
+		{"2", 1, "1 1"},
+		{"1 1", 2, "1 0 1 0 1 0"},
+		{"0 2 1 2", 1, "0 2 1 1 1"}, {"0 2 1 1 2", 1, "0 2 1 1 1 1"}, {"1 2 0 2 2", 1, "1 2 0 2 1 2 1"},
+		{"0 1 3 2", 0, "0 1 3 1"},
+	} {
+		w, _ := Parse(g.worm)
+		if next := w.Next(g.n).String(); next != g.expected {
+			t.Errorf("%v at step %v: got %q, expecting %q", g.worm, g.n, next, g.expected)
+		}
+	}
+}
+
+func TestNextShares(t *testing.T) {
+	// the original worm is left unchanged
+	w, _ := Parse("1 2 2")
+	w.Next(2)
+	w[:1].Next(1)
+	if w.String() != "1 2 2" {
+		t.Errorf("worm modified: %v", w)
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for _, g := range []struct {
+		worm, ordinal string
+	}{
+		{"", "0"},
+		{"0", "1"},
+		{"0 0", "2"},
+		{"1", "ω"},
+		{"1 0", "ω + 1"},
+		{"0 1", "ω"},
+		{"1 1", "ω ^ (2)"},
+		{"2", "ω ^ (ω)"},
+	} {
+		w, _ := Parse(g.worm)
+		if s := w.Ordinal().String(); s != g.ordinal {
+			t.Errorf("%q: got %q, expecting %q", g.worm, s, g.ordinal)
+		}
+	}
+}
+
+func TestOrdinalFinite(t *testing.T) {
+	// worms with a finite ordinal die after that many steps
+	for _, text := range []string{"", "0", "0 0", "0 0 0 0"} {
+		w, _ := Parse(text)
+		s := NewStepper(w)
+		for s.Next() {
+		}
+		if o := w.Ordinal().String(); o != fmt.Sprint(s.Step()) {
+			t.Errorf("%q: ordinal %v, dead after %v steps", text, o, s.Step())
+		}
+	}
+}
+
+func TestStepDecreasesOrdinal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		w := make(Worm, 1+rng.Intn(5))
+		for j := range w {
+			w[j] = rng.Intn(3)
+		}
+		s := NewStepper(w)
+		before := s.Worm()
+		for s.Step() < 20 && len(s.Worm()) < 1000 && s.Next() {
+			if err := CheckStep(before, s.Worm()); err != nil {
+				t.Fatal(err)
+			}
+			before = s.Worm()
+		}
+	}
+}
+
+func TestStepper(t *testing.T) {
+	w, _ := Parse("1 1")
+	s := NewStepper(w)
+	for s.Next() {
+	}
+	// 1 1 -> 1 0 1 0 -> 1 0 1 -> 1 0 0 0 0 0 -> ... -> 1 -> 0 ... 0 -> ... -> empty
+	if s.Step() != 19 || !s.Worm().IsDead() {
+		t.Errorf("worm dead after %v steps: %v", s.Step(), s.Worm().IsDead())
+	}
+	if s.Next() {
+		t.Errorf("step after death")
+	}
+}